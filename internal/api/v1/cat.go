@@ -11,6 +11,7 @@ func init() {
 // Cat is a cat.
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 type Cat struct {
 	metav1.TypeMeta `json:",inline"`
 	// metadata contains the standard object metadata.
@@ -42,4 +43,36 @@ type CatStatus struct {
 	// +required
 	// +kubebuilder:example=false
 	Sleepy bool `json:"sleepy"`
+	// observedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// conditions represent the latest available observations of the cat's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GetConditions returns the conditions of the cat.
+func (c *Cat) GetConditions() []metav1.Condition {
+	if c.Status == nil {
+		return nil
+	}
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the cat.
+func (c *Cat) SetConditions(conditions []metav1.Condition) {
+	if c.Status == nil {
+		c.Status = &CatStatus{}
+	}
+	c.Status.Conditions = conditions
+}
+
+// SetObservedGeneration sets the generation observed by the controller.
+func (c *Cat) SetObservedGeneration(generation int64) {
+	if c.Status == nil {
+		c.Status = &CatStatus{}
+	}
+	c.Status.ObservedGeneration = generation
 }
@@ -0,0 +1,20 @@
+// Package v2 contains the v2 API definitions for the test.appthrust.com API group.
+package v2
+
+// +groupName=test.appthrust.com
+// +versionName=v2
+// +kubebuilder:object:generate=true
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "test.appthrust.com", Version: "v2"}
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+	// AddToScheme adds the types in this group-version to the given scheme
+	AddToScheme = SchemeBuilder.AddToScheme
+)
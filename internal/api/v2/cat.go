@@ -0,0 +1,61 @@
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&Cat{}, &CatList{})
+}
+
+// Cat is a cat.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+type Cat struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata contains the standard object metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// spec defines the desired state of the Cat.
+	// +required
+	Spec CatSpec `json:"spec"`
+	// status defines the observed state of the Cat.
+	// +optional
+	Status *CatStatus `json:"status,omitempty"`
+}
+
+// CatList contains a list of Cat.
+// +kubebuilder:object:root=true
+type CatList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cat `json:"items"`
+}
+
+// CatSpec defines the desired state of Cat.
+type CatSpec struct {
+	// color is the color of the cat's fur.
+	// +optional
+	Color string `json:"color,omitempty"`
+	// lives is the number of lives the cat has left.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=9
+	Lives int32 `json:"lives,omitempty"`
+}
+
+// CatStatus defines the observed state of Cat.
+type CatStatus struct {
+	// sleepy represents if the cat is sleepy.
+	// +required
+	// +kubebuilder:example=false
+	Sleepy bool `json:"sleepy"`
+	// observedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// conditions represent the latest available observations of the cat's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
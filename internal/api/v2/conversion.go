@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"encoding/json"
+
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+)
+
+// SpecAnnotation is the annotation of a v1 Cat preserving the v2 spec, which v1 cannot represent.
+const SpecAnnotation = "test.appthrust.com/v2-spec"
+
+// ToV1 converts a v2 Cat to the v1 hub, keeping its spec in the [SpecAnnotation] annotation.
+func ToV1(cat *Cat) *v1.Cat {
+	out := &v1.Cat{ObjectMeta: *cat.ObjectMeta.DeepCopy()}
+	if cat.Spec != (CatSpec{}) {
+		spec, _ := json.Marshal(cat.Spec) // CatSpec only holds scalars and always marshals.
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[SpecAnnotation] = string(spec)
+	}
+	if cat.Status != nil {
+		out.Status = &v1.CatStatus{
+			Sleepy:             cat.Status.Sleepy,
+			ObservedGeneration: cat.Status.ObservedGeneration,
+			Conditions:         cat.DeepCopy().Status.Conditions,
+		}
+	}
+	return out
+}
+
+// FromV1 converts the v1 hub to a v2 Cat, restoring its spec from the [SpecAnnotation] annotation.
+// An annotation that does not hold a valid spec is dropped.
+func FromV1(cat *v1.Cat) *Cat {
+	out := &Cat{ObjectMeta: *cat.ObjectMeta.DeepCopy()}
+	if spec, ok := out.Annotations[SpecAnnotation]; ok {
+		_ = json.Unmarshal([]byte(spec), &out.Spec)
+		delete(out.Annotations, SpecAnnotation)
+		if len(out.Annotations) == 0 {
+			out.Annotations = nil
+		}
+	}
+	if cat.Status != nil {
+		out.Status = &CatStatus{
+			Sleepy:             cat.Status.Sleepy,
+			ObservedGeneration: cat.Status.ObservedGeneration,
+			Conditions:         cat.DeepCopy().Status.Conditions,
+		}
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+// Package fbuilder provides a typed, composable builder registering functional reconcilers with a controller-runtime manager.
+//
+// A controller is described by piping options into [For] and finished with [Complete]:
+//
+//	F.Pipe3(
+//		fbuilder.For[v1.Cat](),
+//		fbuilder.Owns[corev1.ConfigMap](),
+//		fbuilder.WithPredicates(predicate.GenerationChangedPredicate{}),
+//		fbuilder.Complete(mgr, reconcileCat),
+//	)
+package fbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fhandler"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Builder describes a controller. It is an immutable value built with [For] and the options of this package.
+type Builder struct {
+	forObject  client.Object
+	owns       []owned
+	watches    []watch
+	predicates []predicate.Predicate
+	name       string
+	options    controller.Options
+}
+
+// owned is an owned type together with the predicates filtering its events.
+type owned struct {
+	object     client.Object
+	predicates []predicate.Predicate
+}
+
+// watch is a watched type together with the handler of its events and the predicates filtering them.
+type watch struct {
+	object     client.Object
+	handler    fhandler.Handler
+	predicates []predicate.Predicate
+}
+
+// Option transforms a Builder.
+type Option = func(Builder) Builder
+
+// For starts describing a controller reconciling objects of type T.
+func For[T any, OP fclient.ObjectPointer[T]]() Builder {
+	return Builder{forObject: OP(new(T))}
+}
+
+// Owns watches objects of type U and reconciles their controller owner of the For type.
+// Only the events passing every predicate are handled.
+func Owns[U any, OP fclient.ObjectPointer[U]](predicates ...predicate.Predicate) Option {
+	return func(b Builder) Builder {
+		b.owns = append(append([]owned{}, b.owns...), owned{object: OP(new(U)), predicates: predicates})
+		return b
+	}
+}
+
+// Watches watches objects of type V and reconciles the requests computed by mapFn, as described in [fhandler.MapTo].
+// Only the events passing every predicate are handled.
+func Watches[V any, OP fclient.ObjectPointer[V]](mapFn fhandler.MapFunc[OP], predicates ...predicate.Predicate) Option {
+	return WatchesWith[V, OP](fhandler.MapTo(mapFn), predicates...)
+}
+
+// WatchesWith watches objects of type V and handles their events with h.
+// Only the events passing every predicate are handled.
+func WatchesWith[V any, OP fclient.ObjectPointer[V]](h fhandler.Handler, predicates ...predicate.Predicate) Option {
+	return func(b Builder) Builder {
+		b.watches = append(append([]watch{}, b.watches...), watch{object: OP(new(V)), handler: h, predicates: predicates})
+		return b
+	}
+}
+
+// WithPredicates filters the events of the For type with the given predicates, such as the ones compiled by
+// fpredicate.ToPredicate. Events of owned and watched types are filtered by the predicates given to [Owns],
+// [Watches] and [WatchesWith].
+func WithPredicates(predicates ...predicate.Predicate) Option {
+	return func(b Builder) Builder {
+		b.predicates = append(append([]predicate.Predicate{}, b.predicates...), predicates...)
+		return b
+	}
+}
+
+// Named sets the name of the controller, which defaults to the lowercased kind of the For type.
+func Named(name string) Option {
+	return func(b Builder) Builder {
+		b.name = name
+		return b
+	}
+}
+
+// WithOptions sets the controller options, such as MaxConcurrentReconciles.
+func WithOptions(options controller.Options) Option {
+	return func(b Builder) Builder {
+		b.options = options
+		return b
+	}
+}
+
+// Complete registers the described controller with mgr, reconciling with f.
+//
+// Every reconcile and map function runs with the Env built by [fmanager.EnvFor] for the controller's name.
+// Errors and panics raised while building the controller are returned as a Left.
+func Complete(mgr manager.Manager, f freconcile.Reconciler) func(Builder) fclient.Either[fclient.Unit] {
+	return func(b Builder) (result fclient.Either[fclient.Unit]) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = ET.Left[fclient.Unit](fmt.Errorf("failed to build controller: %v", r))
+			}
+		}()
+		name, err := controllerName(mgr, b)
+		if err != nil {
+			return ET.Left[fclient.Unit](err)
+		}
+		env := fmanager.EnvFor(mgr, name)
+		blder := builder.ControllerManagedBy(mgr).
+			For(b.forObject, builder.WithPredicates(b.predicates...)).
+			Named(name).
+			WithOptions(b.options)
+		for _, o := range b.owns {
+			blder = blder.Owns(o.object, builder.WithPredicates(o.predicates...))
+		}
+		for _, w := range b.watches {
+			blder = blder.Watches(w.object, w.handler(env), builder.WithPredicates(w.predicates...))
+		}
+		if err := blder.Complete(fmanager.Reconciler(mgr, name, f)); err != nil {
+			return ET.Left[fclient.Unit](err)
+		}
+		return ET.Right[error](fclient.UnitValue)
+	}
+}
+
+// Register is the [fmanager.Setup] completing the described controller with f, for use with [fmanager.Register].
+func Register(f freconcile.Reconciler) func(Builder) fmanager.Setup {
+	return func(b Builder) fmanager.Setup {
+		return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+			return func() fclient.Either[fclient.Unit] {
+				return Complete(mgr, f)(b)
+			}
+		}
+	}
+}
+
+// controllerName returns the explicit name of b, or the lowercased kind of its For type.
+func controllerName(mgr manager.Manager, b Builder) (string, error) {
+	if b.name != "" {
+		return b.name, nil
+	}
+	gvk, err := apiutil.GVKForObject(b.forObject, mgr.GetScheme())
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(gvk.Kind), nil
+}
@@ -0,0 +1,265 @@
+package fbuilder_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fbuilder"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	"github.com/appthrust/fcr/pkg/fpredicate"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFbuilder(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fbuilder Suite")
+}
+
+// newManager returns a manager for a cluster that is never contacted, aware of the test API types.
+func newManager() manager.Manager {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	mgr, err := manager.New(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return mgr
+}
+
+// lockedInformer is a fake informer safe for registering handlers while events are injected, which
+// controllertest.FakeInformer is not.
+type lockedInformer struct {
+	*controllertest.FakeInformer
+	mu sync.Mutex
+}
+
+func (i *lockedInformer) AddEventHandler(h toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.FakeInformer.AddEventHandler(h)
+}
+
+func (i *lockedInformer) AddEventHandlerWithResyncPeriod(h toolscache.ResourceEventHandler, resync time.Duration) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.FakeInformer.AddEventHandlerWithResyncPeriod(h, resync)
+}
+
+func (i *lockedInformer) AddEventHandlerWithOptions(h toolscache.ResourceEventHandler, opts toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.FakeInformer.AddEventHandlerWithOptions(h, opts)
+}
+
+func (i *lockedInformer) Add(obj metav1.Object) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.FakeInformer.Add(obj)
+}
+
+// lockedInformers is a fake cache serving a lockedInformer for each of the types it was made for.
+type lockedInformers struct {
+	*informertest.FakeInformers
+	informers map[reflect.Type]*lockedInformer
+}
+
+func (c *lockedInformers) GetInformer(_ context.Context, obj client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	informer, ok := c.informers[reflect.TypeOf(obj)]
+	if !ok {
+		return nil, fmt.Errorf("no informer for %T", obj)
+	}
+	return informer, nil
+}
+
+// newFakeManager returns a manager whose cache is made of fake informers for objs, so that events can be injected
+// into its controllers without a cluster.
+func newFakeManager(objs ...client.Object) (manager.Manager, map[reflect.Type]*lockedInformer) {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	informers := &lockedInformers{
+		FakeInformers: &informertest.FakeInformers{Scheme: scheme},
+		informers:     map[reflect.Type]*lockedInformer{},
+	}
+	for _, obj := range objs {
+		informers.informers[reflect.TypeOf(obj)] = &lockedInformer{FakeInformer: &controllertest.FakeInformer{Synced: true}}
+	}
+	mgr, err := manager.New(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		NewCache: func(*rest.Config, cache.Options) (cache.Cache, error) {
+			return informers, nil
+		},
+		NewClient: func(*rest.Config, client.Options) (client.Client, error) {
+			return fake.NewClientBuilder().WithScheme(scheme).Build(), nil
+		},
+		MapperProvider: func(*rest.Config, *http.Client) (apimeta.RESTMapper, error) {
+			mapper := apimeta.NewDefaultRESTMapper(nil)
+			for gvk := range scheme.AllKnownTypes() {
+				mapper.Add(gvk, apimeta.RESTScopeNamespace)
+			}
+			return mapper, nil
+		},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return mgr, informers.informers
+}
+
+var _ = Describe(
+	"Complete", func() {
+
+		var names int
+
+		// uniqueName avoids clashing with the controller names registered by other specs.
+		uniqueName := func() string {
+			names++
+			return fmt.Sprintf("cat-%d", names)
+		}
+
+		reconcileCat := func(reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+			return RIOE.Right[fclient.Env, error](reconcile.Result{})
+		}
+
+		It(
+			"should register a controller with owned and watched types", func() {
+				result := F.Pipe5(
+					fbuilder.For[v1.Cat](),
+					fbuilder.Owns[corev1.ConfigMap](),
+					fbuilder.Watches(func(secret *corev1.Secret) fclient.ReaderIOEither[[]reconcile.Request] {
+						return RIOE.Right[fclient.Env, error]([]reconcile.Request{
+							{NamespacedName: types.NamespacedName{Name: secret.Labels["cat"], Namespace: secret.Namespace}},
+						})
+					}),
+					fbuilder.WithPredicates(predicate.GenerationChangedPredicate{}),
+					fbuilder.Named(uniqueName()),
+					fbuilder.Complete(newManager(), reconcileCat),
+				)
+				Expect(ET.IsRight(result)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should register as an fmanager Setup", func() {
+				mgr := newManager()
+				result := fmanager.Register(
+					F.Pipe2(fbuilder.For[v1.Cat](), fbuilder.Named(uniqueName()), fbuilder.Register(reconcileCat)),
+				)(mgr)()
+				Expect(result).To(Equal(ET.Right[error](mgr)))
+			},
+		)
+
+		It(
+			"should return a Left for types unknown to the manager", func() {
+				result := F.Pipe1(
+					fbuilder.For[appsv1.Deployment](),
+					fbuilder.Complete(newManager(), reconcileCat),
+				)
+				_, err := ET.UnwrapError(result)
+				Expect(err).To(MatchError(ContainSubstring("no kind is registered")))
+			},
+		)
+
+		It(
+			"should return a Left when the controller name is taken", func() {
+				mgr := newManager()
+				name := uniqueName()
+				register := F.Flow2(fbuilder.Named(name), fbuilder.Complete(mgr, reconcileCat))
+				Expect(ET.IsRight(register(fbuilder.For[v1.Cat]()))).To(BeTrue())
+				_, err := ET.UnwrapError(register(fbuilder.For[v1.Cat]()))
+				Expect(err).To(MatchError(ContainSubstring("already exists")))
+			},
+		)
+
+		It(
+			"should recover panics raised while building", func() {
+				result := F.Pipe2(
+					fbuilder.For[v1.Cat](),
+					fbuilder.WithOptions(controller.Options{MaxConcurrentReconciles: 2}),
+					fbuilder.Complete(nil, reconcileCat),
+				)
+				_, err := ET.UnwrapError(result)
+				Expect(err).To(MatchError(ContainSubstring("failed to build controller")))
+			},
+		)
+
+		It(
+			"should run the reconciler and map functions, applying predicates to their own watch only", func() {
+				mgr, informers := newFakeManager(&v1.Cat{}, &corev1.Secret{})
+				ctx, cancel := context.WithCancel(context.TODO())
+				DeferCleanup(cancel)
+				catInformer, secretInformer := informers[reflect.TypeFor[*v1.Cat]()], informers[reflect.TypeFor[*corev1.Secret]()]
+
+				reconciled := make(chan reconcile.Request, 10)
+				result := F.Pipe4(
+					fbuilder.For[v1.Cat](),
+					fbuilder.Watches(func(secret *corev1.Secret) fclient.ReaderIOEither[[]reconcile.Request] {
+						return RIOE.Right[fclient.Env, error]([]reconcile.Request{
+							{NamespacedName: types.NamespacedName{Name: secret.Labels["cat"], Namespace: secret.Namespace}},
+						})
+					}),
+					fbuilder.WithPredicates(fpredicate.ToPredicate(fpredicate.InNamespace[*v1.Cat]("default"))),
+					fbuilder.Named(uniqueName()),
+					fbuilder.Complete(mgr, func(req reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+						reconciled <- req
+						return RIOE.Right[fclient.Env, error](reconcile.Result{})
+					}),
+				)
+				Expect(ET.IsRight(result)).To(BeTrue())
+				go func() {
+					defer GinkgoRecover()
+					Expect(mgr.Start(ctx)).To(Succeed())
+				}()
+
+				request := func(name, namespace string) reconcile.Request {
+					return reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+				}
+				tom := &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default"}}
+				Eventually(func(g Gomega) {
+					catInformer.Add(tom)
+					g.Expect(reconciled).To(Receive(Equal(request("tom", "default"))))
+				}).Should(Succeed())
+
+				catInformer.Add(&v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "felix", Namespace: "kube-system"}})
+				Consistently(reconciled, 100*time.Millisecond).ShouldNot(Receive(Equal(request("felix", "kube-system"))), "the For predicate filters cats")
+
+				secretInformer.Add(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      "food",
+					Namespace: "kube-system",
+					Labels:    map[string]string{"cat": "felix"},
+				}})
+				Eventually(reconciled).Should(Receive(Equal(request("felix", "kube-system"))), "the For predicate must not filter secrets")
+			},
+		)
+	},
+)
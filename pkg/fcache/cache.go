@@ -0,0 +1,118 @@
+// Package fcache provides functional reads from the controller-runtime informer [cache.Cache].
+//
+// The operations mirror the reads of [fclient] but are served by Env.Cache instead of Env.Client, and [Live] serves
+// the reads of a pipeline by Env.APIReader, so a pipeline states explicitly whether it reads cached or live state.
+// The Env of a manager has a Client that already reads from the cache, so the reads of [fclient] are cached there
+// unless wrapped in [Live].
+package fcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	IOE "github.com/IBM/fp-go/ioeither"
+	O "github.com/IBM/fp-go/option"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNoCache is returned by the operations of this package when the Env has no Cache.
+var ErrNoCache = errors.New("fcache: the Env has no Cache")
+
+// ErrNoAPIReader is returned by [Live] when the Env has no APIReader.
+var ErrNoAPIReader = errors.New("fcache: the Env has no APIReader")
+
+// Get is [fclient.Get] reading from Env.Cache.
+func Get[T any, OP fclient.ObjectPointer[T]](p fclient.GetParams) fclient.ReaderIOEither[OP] {
+	return cached(fclient.Get[T, OP](p))
+}
+
+// GetOption is [fclient.GetOption] reading from Env.Cache: an object missing from the cache yields None.
+func GetOption[T any, OP fclient.ObjectPointer[T]](p fclient.GetParams) fclient.ReaderIOEither[O.Option[OP]] {
+	return cached(fclient.GetOption[T, OP](p))
+}
+
+// ListItems is [fclient.ListItems] reading from Env.Cache.
+func ListItems[O any, OL any, OP fclient.ObjectPointer[O], OLP fclient.ObjectListPointer[OL]](p fclient.ListParams) fclient.ReaderIOEither[[]OP] {
+	return cached(fclient.ListItems[O, OL, OP, OLP](p))
+}
+
+// WaitForCacheSync waits until every informer of Env.Cache has synced, failing when Env.Ctx ends first.
+func WaitForCacheSync() fclient.ReaderIOEither[fclient.Unit] {
+	return withCache(func(env fclient.Env) (fclient.Unit, error) {
+		if !env.Cache.WaitForCacheSync(env.Ctx) {
+			return fclient.UnitValue, fmt.Errorf("fcache: cache did not sync: %w", context.Cause(env.Ctx))
+		}
+		return fclient.UnitValue, nil
+	})
+}
+
+// GetInformer returns the informer of Env.Cache for objects of type T, starting it if needed.
+func GetInformer[T any, OP fclient.ObjectPointer[T]](opts ...cache.InformerGetOption) fclient.ReaderIOEither[cache.Informer] {
+	return withCache(func(env fclient.Env) (cache.Informer, error) {
+		return env.Cache.GetInformer(env.Ctx, OP(new(T)), opts...)
+	})
+}
+
+// WithCache runs a sub-pipeline with Env.Cache set to c.
+func WithCache[T any](c cache.Cache) func(fclient.ReaderIOEither[T]) fclient.ReaderIOEither[T] {
+	return func(rioe fclient.ReaderIOEither[T]) fclient.ReaderIOEither[T] {
+		return func(env fclient.Env) fclient.IOEither[T] {
+			env.Cache = c
+			return rioe(env)
+		}
+	}
+}
+
+// Live runs rioe with its reads, such as [fclient.Get] and [fclient.List], served by Env.APIReader, for state that
+// must not be stale, e.g. right before acting on an external system. Writes still go to Env.Client.
+func Live[T any](rioe fclient.ReaderIOEither[T]) fclient.ReaderIOEither[T] {
+	return func(env fclient.Env) fclient.IOEither[T] {
+		if env.APIReader == nil {
+			return RIOE.Left[fclient.Env, T](ErrNoAPIReader)(env)
+		}
+		env.Client = &readerClient{Client: env.Client, reader: env.APIReader}
+		return rioe(env)
+	}
+}
+
+// cached runs rioe with its reads served by Env.Cache. Writes still go to Env.Client.
+func cached[T any](rioe fclient.ReaderIOEither[T]) fclient.ReaderIOEither[T] {
+	return func(env fclient.Env) fclient.IOEither[T] {
+		if env.Cache == nil {
+			return RIOE.Left[fclient.Env, T](ErrNoCache)(env)
+		}
+		env.Client = &readerClient{Client: env.Client, reader: env.Cache}
+		return rioe(env)
+	}
+}
+
+// withCache lifts f, which uses Env.Cache, failing with [ErrNoCache] when there is none.
+func withCache[T any](f func(env fclient.Env) (T, error)) fclient.ReaderIOEither[T] {
+	return func(env fclient.Env) fclient.IOEither[T] {
+		return IOE.TryCatchError(func() (T, error) {
+			if env.Cache == nil {
+				var zero T
+				return zero, ErrNoCache
+			}
+			return f(env)
+		})
+	}
+}
+
+// readerClient is a client whose reads are served by reader.
+type readerClient struct {
+	client.Client
+	reader client.Reader
+}
+
+func (c *readerClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *readerClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
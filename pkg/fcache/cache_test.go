@@ -0,0 +1,194 @@
+package fcache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ET "github.com/IBM/fp-go/either"
+	O "github.com/IBM/fp-go/option"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fcache"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+func TestFcache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fcache Suite")
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+// readerCache is a fake cache whose reads are served by a fake client holding the cached objects.
+type readerCache struct {
+	*informertest.FakeInformers
+	reader client.Reader
+}
+
+func (c *readerCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *readerCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func newCache(objs ...client.Object) *readerCache {
+	return &readerCache{
+		FakeInformers: &informertest.FakeInformers{Scheme: newScheme()},
+		reader:        fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objs...).Build(),
+	}
+}
+
+// cachedClient is a client reading from a cache, like the client of a manager.
+type cachedClient struct {
+	client.Client
+	cache client.Reader
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.cache.Get(ctx, key, obj, opts...)
+}
+
+func (c *cachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.cache.List(ctx, list, opts...)
+}
+
+func cat(name string, lbls map[string]string) *v1.Cat {
+	return &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: lbls}}
+}
+
+var _ = Describe(
+	"Cached reads", func() {
+
+		var env fclient.Env
+
+		BeforeEach(
+			func() {
+				// The live cluster has a new cat the cache has not seen yet, and a label change.
+				live := fake.NewClientBuilder().WithScheme(newScheme()).
+					WithObjects(cat("tom", map[string]string{"mood": "hungry"}), cat("felix", nil)).
+					Build()
+				env = fclient.Env{Ctx: context.TODO(), Client: live, Cache: newCache(cat("tom", map[string]string{"mood": "sleepy"}))}
+			},
+		)
+
+		It(
+			"should get objects from the cache rather than the client", func() {
+				key := client.ObjectKey{Name: "tom", Namespace: "default"}
+				cached, err := ET.UnwrapError(fcache.Get[v1.Cat](fclient.ToGetParams(key))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cached.Labels).To(HaveKeyWithValue("mood", "sleepy"))
+
+				live, err := ET.UnwrapError(fclient.Get[v1.Cat](fclient.ToGetParams(key))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(live.Labels).To(HaveKeyWithValue("mood", "hungry"))
+			},
+		)
+
+		It(
+			"should yield None for objects missing from the cache", func() {
+				key := client.ObjectKey{Name: "felix", Namespace: "default"}
+				result, err := ET.UnwrapError(fcache.GetOption[v1.Cat](fclient.ToGetParams(key))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(O.IsNone(result)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should list objects from the cache", func() {
+				cats, err := ET.UnwrapError(fcache.ListItems[v1.Cat, v1.CatList](fclient.ToListParams(client.InNamespace("default")))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cats).To(HaveLen(1))
+				Expect(cats[0].Name).To(Equal("tom"))
+			},
+		)
+
+		It(
+			"should read live state with Live when the client is cached", func() {
+				env.APIReader = env.Client
+				env.Client = &cachedClient{Client: env.Client, cache: env.Cache}
+				key := client.ObjectKey{Name: "tom", Namespace: "default"}
+				cached, err := ET.UnwrapError(fclient.Get[v1.Cat](fclient.ToGetParams(key))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cached.Labels).To(HaveKeyWithValue("mood", "sleepy"))
+
+				live, err := ET.UnwrapError(fcache.Live(fclient.Get[v1.Cat](fclient.ToGetParams(key)))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(live.Labels).To(HaveKeyWithValue("mood", "hungry"))
+
+				cats, err := ET.UnwrapError(fcache.Live(fclient.ListItems[v1.Cat, v1.CatList](fclient.ToListParams(client.InNamespace("default"))))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cats).To(HaveLen(2))
+
+				env.APIReader = nil
+				_, err = ET.UnwrapError(fcache.Live(fclient.Get[v1.Cat](fclient.ToGetParams(key)))(env)())
+				Expect(err).To(MatchError(fcache.ErrNoAPIReader))
+			},
+		)
+
+		It(
+			"should fail without a cache", func() {
+				env.Cache = nil
+				key := client.ObjectKey{Name: "tom", Namespace: "default"}
+				_, err := ET.UnwrapError(fcache.Get[v1.Cat](fclient.ToGetParams(key))(env)())
+				Expect(err).To(MatchError(fcache.ErrNoCache))
+				_, err = ET.UnwrapError(fcache.WaitForCacheSync()(env)())
+				Expect(err).To(MatchError(fcache.ErrNoCache))
+			},
+		)
+
+		It(
+			"should run sub-pipelines with another cache", func() {
+				other := newCache(cat("felix", nil))
+				key := client.ObjectKey{Name: "felix", Namespace: "default"}
+				result := fcache.WithCache[*v1.Cat](other)(fcache.Get[v1.Cat](fclient.ToGetParams(key)))(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+			},
+		)
+	},
+)
+
+var _ = Describe(
+	"Informers", func() {
+
+		It(
+			"should wait for the cache to sync", func() {
+				synced, unsynced := true, false
+				env := fclient.Env{Ctx: context.TODO(), Cache: &informertest.FakeInformers{Synced: &synced}}
+				Expect(ET.IsRight(fcache.WaitForCacheSync()(env)())).To(BeTrue())
+
+				ctx, cancel := context.WithCancelCause(context.TODO())
+				cancel(errors.New("shutting down"))
+				env = fclient.Env{Ctx: ctx, Cache: &informertest.FakeInformers{Synced: &unsynced}}
+				_, err := ET.UnwrapError(fcache.WaitForCacheSync()(env)())
+				Expect(err).To(MatchError(ContainSubstring("shutting down")))
+			},
+		)
+
+		It(
+			"should get the informer of a type", func() {
+				env := fclient.Env{Ctx: context.TODO(), Cache: &informertest.FakeInformers{Scheme: newScheme()}}
+				informer, err := ET.UnwrapError(fcache.GetInformer[v1.Cat]()(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(informer).To(BeAssignableToTypeOf(&controllertest.FakeInformer{}))
+
+				env.Cache = &informertest.FakeInformers{Scheme: newScheme(), Error: errors.New("no informer")}
+				_, err = ET.UnwrapError(fcache.GetInformer[v1.Cat]()(env)())
+				Expect(err).To(MatchError("no informer"))
+			},
+		)
+	},
+)
@@ -0,0 +1,123 @@
+package fcache
+
+import (
+	"errors"
+	"fmt"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/prometheus/client_golang/prometheus"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// handlerErrors counts the informer event handlers of [OnEvents] that failed, by object type and event.
+var handlerErrors = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fcr_cache_handler_errors_total",
+		Help: "Number of informer event handlers that failed, by object type and event.",
+	},
+	[]string{"type", "event"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(handlerErrors)
+}
+
+// ErrNotRegistered is returned when removing a zero Registration, which holds no handlers.
+var ErrNotRegistered = errors.New("fcache: the Registration holds no handlers")
+
+// Registration is the handle of handlers added to an informer by [OnEvents].
+type Registration struct {
+	informer cache.Informer
+	handle   toolscache.ResourceEventHandlerRegistration
+}
+
+// HasSynced reports whether the handlers have been notified of every object of the informer's initial list.
+func (r Registration) HasSynced() bool {
+	if r.informer == nil {
+		return false
+	}
+	return r.handle == nil || r.handle.HasSynced()
+}
+
+// Remove removes the handlers from the informer. They are not called for events delivered afterwards.
+// Removing a zero Registration fails with [ErrNotRegistered].
+func (r Registration) Remove() fclient.ReaderIOEither[fclient.Unit] {
+	return func(fclient.Env) fclient.IOEither[fclient.Unit] {
+		return func() fclient.Either[fclient.Unit] {
+			if r.informer == nil {
+				return ET.Left[fclient.Unit](ErrNotRegistered)
+			}
+			return ET.TryCatchError(fclient.UnitValue, r.informer.RemoveEventHandler(r.handle))
+		}
+	}
+}
+
+// OnEvents adds handlers for the events on objects of type T to the informer of Env.Cache, returning the
+// Registration to remove them with.
+//
+// The handlers receive deep copies of the objects of the informer's cache, so they may mutate them without
+// corrupting the cache shared with every other reader. Nil handlers are skipped. Deleted objects whose final
+// state is unknown are unwrapped from their tombstone, and objects of another type are ignored. The handlers run
+// in the Env of the registration, so its context should live as long as the handlers, like the manager's.
+// A failing handler is logged and counted in the fcr_cache_handler_errors_total metric; informer events cannot
+// be retried.
+func OnEvents[T any, OP fclient.ObjectPointer[T]](
+	onAdd func(obj OP) fclient.ReaderIOEither[fclient.Unit],
+	onUpdate func(oldObj, newObj OP) fclient.ReaderIOEither[fclient.Unit],
+	onDelete func(obj OP) fclient.ReaderIOEither[fclient.Unit],
+) fclient.ReaderIOEither[Registration] {
+	return withCache(func(env fclient.Env) (Registration, error) {
+		informer, err := env.Cache.GetInformer(env.Ctx, OP(new(T)))
+		if err != nil {
+			return Registration{}, err
+		}
+		run := func(event string, obj OP, rioe fclient.ReaderIOEither[fclient.Unit]) {
+			if err := ET.ToError(rioe(env)()); err != nil {
+				handlerErrors.WithLabelValues(fmt.Sprintf("%T", obj), event).Inc()
+				log.FromContext(env.Ctx).Error(err, "Informer event handler failed", "event", event, "object", client.ObjectKeyFromObject(obj))
+			}
+		}
+		handlers := toolscache.ResourceEventHandlerFuncs{}
+		if onAdd != nil {
+			handlers.AddFunc = func(obj any) {
+				if typed, ok := obj.(OP); ok {
+					run("add", typed, onAdd(deepCopy(typed)))
+				}
+			}
+		}
+		if onUpdate != nil {
+			handlers.UpdateFunc = func(oldObj, newObj any) {
+				typedOld, okOld := oldObj.(OP)
+				typedNew, okNew := newObj.(OP)
+				if okOld && okNew {
+					run("update", typedNew, onUpdate(deepCopy(typedOld), deepCopy(typedNew)))
+				}
+			}
+		}
+		if onDelete != nil {
+			handlers.DeleteFunc = func(obj any) {
+				if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if typed, ok := obj.(OP); ok {
+					run("delete", typed, onDelete(deepCopy(typed)))
+				}
+			}
+		}
+		handle, err := informer.AddEventHandler(handlers)
+		if err != nil {
+			return Registration{}, err
+		}
+		return Registration{informer: informer, handle: handle}, nil
+	})
+}
+
+// deepCopy copies obj, an object of the informer's cache, before it is handed to a handler.
+func deepCopy[OP client.Object](obj OP) OP {
+	return obj.DeepCopyObject().(OP)
+}
@@ -0,0 +1,194 @@
+package fcache_test
+
+import (
+	"context"
+	"errors"
+
+	ET "github.com/IBM/fp-go/either"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fcache"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// handle is the registration of a handler to a stubInformer.
+type handle struct {
+	synced bool
+}
+
+func (h *handle) HasSynced() bool { return h.synced }
+
+// stubInformer delivers arbitrary objects, such as tombstones, to its handlers, and supports removing them.
+type stubInformer struct {
+	*controllertest.FakeInformer
+	handlers map[toolscache.ResourceEventHandlerRegistration]toolscache.ResourceEventHandler
+}
+
+func (i *stubInformer) AddEventHandler(h toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	reg := &handle{synced: true}
+	i.handlers[reg] = h
+	return reg, nil
+}
+
+func (i *stubInformer) RemoveEventHandler(reg toolscache.ResourceEventHandlerRegistration) error {
+	delete(i.handlers, reg)
+	return nil
+}
+
+func (i *stubInformer) add(obj any) {
+	for _, h := range i.handlers {
+		h.OnAdd(obj, false)
+	}
+}
+
+func (i *stubInformer) update(oldObj, newObj any) {
+	for _, h := range i.handlers {
+		h.OnUpdate(oldObj, newObj)
+	}
+}
+
+func (i *stubInformer) delete(obj any) {
+	for _, h := range i.handlers {
+		h.OnDelete(obj)
+	}
+}
+
+// informerCache is a fake cache serving a single informer.
+type informerCache struct {
+	*informertest.FakeInformers
+	informer cache.Informer
+}
+
+func (c *informerCache) GetInformer(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer, error) {
+	return c.informer, nil
+}
+
+var _ = Describe(
+	"OnEvents", func() {
+
+		var (
+			informer *stubInformer
+			env      fclient.Env
+			seen     []string
+			logged   []string
+		)
+
+		BeforeEach(
+			func() {
+				informer = &stubInformer{
+					FakeInformer: &controllertest.FakeInformer{},
+					handlers:     map[toolscache.ResourceEventHandlerRegistration]toolscache.ResourceEventHandler{},
+				}
+				seen, logged = nil, nil
+				logger := funcr.New(func(_, args string) { logged = append(logged, args) }, funcr.Options{})
+				env = fclient.Env{Ctx: log.IntoContext(context.TODO(), logger), Cache: &informerCache{informer: informer}}
+			},
+		)
+
+		record := func(event string) func(*v1.Cat) fclient.ReaderIOEither[fclient.Unit] {
+			return func(cat *v1.Cat) fclient.ReaderIOEither[fclient.Unit] {
+				seen = append(seen, event+" "+cat.Name)
+				return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+			}
+		}
+
+		onUpdate := func(oldObj, newObj *v1.Cat) fclient.ReaderIOEither[fclient.Unit] {
+			seen = append(seen, "update "+oldObj.Name+" to "+newObj.Name)
+			return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+		}
+
+		register := func(
+			onAdd func(*v1.Cat) fclient.ReaderIOEither[fclient.Unit],
+			onUpdate func(oldObj, newObj *v1.Cat) fclient.ReaderIOEither[fclient.Unit],
+			onDelete func(*v1.Cat) fclient.ReaderIOEither[fclient.Unit],
+		) fcache.Registration {
+			reg, err := ET.UnwrapError(fcache.OnEvents(onAdd, onUpdate, onDelete)(env)())
+			Expect(err).NotTo(HaveOccurred())
+			return reg
+		}
+
+		It(
+			"should call the handlers with typed objects, unwrapping tombstones", func() {
+				reg := register(record("add"), onUpdate, record("delete"))
+				Expect(reg.HasSynced()).To(BeTrue())
+				informer.add(cat("tom", nil))
+				informer.update(cat("tom", nil), cat("felix", nil))
+				informer.delete(cat("tom", nil))
+				informer.delete(toolscache.DeletedFinalStateUnknown{Key: "default/felix", Obj: cat("felix", nil)})
+				Expect(seen).To(Equal([]string{"add tom", "update tom to felix", "delete tom", "delete felix"}))
+			},
+		)
+
+		It(
+			"should skip nil handlers and objects of another type", func() {
+				register(record("add"), nil, nil)
+				informer.add(&corev1.ConfigMap{})
+				informer.update(cat("tom", nil), cat("tom", nil))
+				informer.delete(cat("tom", nil))
+				Expect(seen).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should log failing handlers", func() {
+				register(func(*v1.Cat) fclient.ReaderIOEither[fclient.Unit] {
+					return RIOE.Left[fclient.Env, fclient.Unit](errors.New("boom"))
+				}, nil, nil)
+				informer.add(cat("tom", nil))
+				Expect(logged).To(ConsistOf(And(
+					ContainSubstring("Informer event handler failed"),
+					ContainSubstring("boom"),
+					ContainSubstring(`"event"="add"`),
+				)))
+			},
+		)
+
+		It(
+			"should stop calling the handlers once removed", func() {
+				reg := register(record("add"), nil, nil)
+				Expect(ET.IsRight(reg.Remove()(env)())).To(BeTrue())
+				informer.add(cat("tom", nil))
+				Expect(seen).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should hand copies of the cached objects to the handlers", func() {
+				register(func(cat *v1.Cat) fclient.ReaderIOEither[fclient.Unit] {
+					cat.Labels = map[string]string{"mood": "grumpy"}
+					return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+				}, nil, nil)
+				cached := cat("tom", nil)
+				informer.add(cached)
+				Expect(cached.Labels).To(BeNil())
+			},
+		)
+
+		It(
+			"should fail to remove a zero registration", func() {
+				var reg fcache.Registration
+				Expect(reg.HasSynced()).To(BeFalse())
+				_, err := ET.UnwrapError(reg.Remove()(env)())
+				Expect(err).To(MatchError(fcache.ErrNotRegistered))
+			},
+		)
+
+		It(
+			"should fail without a cache", func() {
+				env.Cache = nil
+				_, err := ET.UnwrapError(fcache.OnEvents(record("add"), nil, nil)(env)())
+				Expect(err).To(MatchError(fcache.ErrNoCache))
+			},
+		)
+	},
+)
@@ -11,6 +11,8 @@ import (
 	RIOE "github.com/IBM/fp-go/readerioeither"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -33,6 +35,15 @@ var UnitValue = Unit{}
 type Env struct {
 	Ctx    context.Context
 	Client client.Client
+	// Impersonator builds the clients used by impersonation combinators such as [AsUser]. It is optional.
+	Impersonator *Impersonator
+	// Recorder records Kubernetes events emitted with [Event]. It is optional.
+	Recorder record.EventRecorder
+	// Cache serves the cached reads of the fcache package. It is optional.
+	Cache cache.Cache
+	// APIReader reads from the API server, bypassing any cache of Client, for the live reads of the fcache package.
+	// It is optional.
+	APIReader client.Reader
 }
 
 // GetParams contains parameters for Get operations.
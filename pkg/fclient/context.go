@@ -0,0 +1,87 @@
+package fclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+)
+
+// TimeoutError reports that a sub-pipeline scoped by [WithTimeout], [WithDeadline] or [WithContext] ran out of time.
+//
+// It wraps the error returned by the sub-pipeline, so errors.Is(err, context.DeadlineExceeded) keeps working.
+type TimeoutError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("operation timed out: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// IsTimeout reports whether err is, or wraps, a [TimeoutError].
+func IsTimeout(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// WithTimeout runs a sub-pipeline with Env.Ctx bounded by the given timeout.
+//
+// The derived context is cancelled as soon as the sub-pipeline finishes.
+// If the timeout expires before the sub-pipeline succeeds, its error is wrapped in a [TimeoutError].
+func WithTimeout[T any](d time.Duration) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return WithContext[T](func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return context.WithTimeout(ctx, d)
+	})
+}
+
+// WithDeadline runs a sub-pipeline with Env.Ctx bounded by the given deadline.
+//
+// It behaves like [WithTimeout] with an absolute point in time.
+func WithDeadline[T any](t time.Time) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return WithContext[T](func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return context.WithDeadline(ctx, t)
+	})
+}
+
+// WithContext runs a sub-pipeline with Env.Ctx replaced by the context derived from it by f.
+//
+// The returned cancel function is called once the sub-pipeline finishes, so nothing derived from the
+// scoped context outlives it. Errors caused by the derived context's deadline are wrapped in a [TimeoutError];
+// errors caused by the parent context are propagated unchanged.
+func WithContext[T any](f func(context.Context) (context.Context, context.CancelFunc)) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return func(rioe ReaderIOEither[T]) ReaderIOEither[T] {
+		return func(env Env) IOEither[T] {
+			return func() Either[T] {
+				ctx, cancel := f(env.Ctx)
+				defer cancel()
+				scoped := env
+				scoped.Ctx = ctx
+				return ET.MapLeft[T](classifyContextError(env.Ctx, ctx))(rioe(scoped)())
+			}
+		}
+	}
+}
+
+func classifyContextError(parent, scoped context.Context) func(error) error {
+	return func(err error) error {
+		if IsTimeout(err) {
+			return err
+		}
+		if !errors.Is(scoped.Err(), context.DeadlineExceeded) {
+			return err
+		}
+		if parent.Err() != nil {
+			// The parent ran out of time first; the scope did not cause the failure.
+			return err
+		}
+		return &TimeoutError{Err: err}
+	}
+}
@@ -0,0 +1,103 @@
+package fclient_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe(
+	"WithContext", func() {
+
+		// blockingClient returns a client whose Get waits until its context is done.
+		blockingClient := func() client.Client {
+			return fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+				}).
+				Build()
+		}
+
+		getParams := fclient.ToGetParams(client.ObjectKey{Name: "my-config", Namespace: "default"})
+
+		It(
+			"should classify an expired timeout as a TimeoutError", func() {
+				env := fclient.Env{Ctx: context.TODO(), Client: blockingClient()}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.WithTimeout[*corev1.ConfigMap](10*time.Millisecond),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(fclient.IsTimeout(err)).To(BeTrue())
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should classify an expired deadline as a TimeoutError", func() {
+				env := fclient.Env{Ctx: context.TODO(), Client: blockingClient()}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.WithDeadline[*corev1.ConfigMap](time.Now().Add(10*time.Millisecond)),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(fclient.IsTimeout(err)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should not classify a cancelled parent context as a TimeoutError", func() {
+				ctx, cancel := context.WithCancel(context.TODO())
+				cancel()
+				env := fclient.Env{Ctx: ctx, Client: blockingClient()}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.WithTimeout[*corev1.ConfigMap](time.Minute),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+				Expect(fclient.IsTimeout(err)).To(BeFalse())
+			},
+		)
+
+		It(
+			"should pass the derived context to the sub-pipeline and cancel it afterwards", func() {
+				type key struct{}
+				var seen context.Context
+				cl := fake.NewClientBuilder().
+					WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "default"}}).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+							seen = ctx
+							return c.Get(ctx, key, obj, opts...)
+						},
+					}).
+					Build()
+				env := fclient.Env{Ctx: context.TODO(), Client: cl}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.WithContext[*corev1.ConfigMap](func(ctx context.Context) (context.Context, context.CancelFunc) {
+						return context.WithCancel(context.WithValue(ctx, key{}, "scoped"))
+					}),
+				)(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(seen.Value(key{})).To(Equal("scoped"))
+				Expect(seen.Err()).To(MatchError(context.Canceled))
+			},
+		)
+	},
+)
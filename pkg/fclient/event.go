@@ -0,0 +1,32 @@
+package fclient
+
+import (
+	IOE "github.com/IBM/fp-go/ioeither"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Event records a Kubernetes event about obj with Env.Recorder.
+//
+// eventType is one of corev1.EventTypeNormal or corev1.EventTypeWarning.
+// Recording is best effort: the pipeline never fails, and nothing happens when the Env has no Recorder.
+func Event(obj runtime.Object, eventType, reason, message string) ReaderIOEither[Unit] {
+	return func(env Env) IOEither[Unit] {
+		return func() Either[Unit] {
+			if env.Recorder != nil {
+				env.Recorder.Event(obj, eventType, reason, message)
+			}
+			return IOE.Right[error](UnitValue)()
+		}
+	}
+}
+
+// WithRecorder runs a sub-pipeline with Env.Recorder set to r.
+func WithRecorder[T any](r record.EventRecorder) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return func(rioe ReaderIOEither[T]) ReaderIOEither[T] {
+		return func(env Env) IOEither[T] {
+			env.Recorder = r
+			return rioe(env)
+		}
+	}
+}
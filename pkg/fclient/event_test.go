@@ -0,0 +1,40 @@
+package fclient_test
+
+import (
+	"context"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe(
+	"Event", func() {
+
+		obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "default"}}
+
+		It(
+			"should record the event with the recorder set by WithRecorder", func() {
+				recorder := record.NewFakeRecorder(1)
+				result := F.Pipe1(
+					fclient.Event(obj, corev1.EventTypeNormal, "Synced", "config synced"),
+					fclient.WithRecorder[fclient.Unit](recorder),
+				)(fclient.Env{Ctx: context.TODO()})()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(recorder.Events).To(Receive(Equal("Normal Synced config synced")))
+			},
+		)
+
+		It(
+			"should do nothing without a recorder", func() {
+				result := fclient.Event(obj, corev1.EventTypeWarning, "Failed", "boom")(fclient.Env{Ctx: context.TODO()})()
+				Expect(ET.IsRight(result)).To(BeTrue())
+			},
+		)
+	},
+)
@@ -0,0 +1,106 @@
+package fclient
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	ET "github.com/IBM/fp-go/either"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNoImpersonator is returned by impersonation combinators when Env.Impersonator is nil.
+var ErrNoImpersonator = errors.New("impersonation requires Env.Impersonator")
+
+// Impersonator builds clients impersonating other identities from a base [rest.Config].
+//
+// Clients are cached per identity, so repeated sub-pipelines for the same identity share a client.
+// An Impersonator is safe for concurrent use.
+type Impersonator struct {
+	config  *rest.Config
+	options client.Options
+
+	mu      sync.Mutex
+	clients map[string]client.Client
+}
+
+// NewImpersonator creates an Impersonator from the base config and the options used to build each client.
+func NewImpersonator(config *rest.Config, options client.Options) *Impersonator {
+	return &Impersonator{
+		config:  config,
+		options: options,
+		clients: map[string]client.Client{},
+	}
+}
+
+// ClientFor returns the client impersonating the given identity, building it on first use.
+func (i *Impersonator) ClientFor(identity rest.ImpersonationConfig) (client.Client, error) {
+	key := impersonationKey(identity)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if cl, ok := i.clients[key]; ok {
+		return cl, nil
+	}
+	config := rest.CopyConfig(i.config)
+	config.Impersonate = identity
+	cl, err := client.New(config, i.options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client impersonating %q: %w", identity.UserName, err)
+	}
+	i.clients[key] = cl
+	return cl, nil
+}
+
+// Impersonate runs a sub-pipeline with an Env whose client impersonates the given identity.
+//
+// The client is obtained from Env.Impersonator; the sub-pipeline fails with [ErrNoImpersonator] when it is nil.
+// Authorization failures of the impersonated identity surface as regular Forbidden errors.
+func Impersonate[T any](identity rest.ImpersonationConfig) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return func(rioe ReaderIOEither[T]) ReaderIOEither[T] {
+		return func(env Env) IOEither[T] {
+			return func() Either[T] {
+				if env.Impersonator == nil {
+					return ET.Left[T](ErrNoImpersonator)
+				}
+				cl, err := env.Impersonator.ClientFor(identity)
+				if err != nil {
+					return ET.Left[T](err)
+				}
+				impersonated := env
+				impersonated.Client = cl
+				return rioe(impersonated)()
+			}
+		}
+	}
+}
+
+// AsUser runs a sub-pipeline impersonating the given user and groups.
+func AsUser[T any](user string, groups ...string) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return Impersonate[T](rest.ImpersonationConfig{UserName: user, Groups: groups})
+}
+
+// AsServiceAccount runs a sub-pipeline impersonating the given service account.
+//
+// Only the service account's user name is impersonated: the API server adds the groups of service accounts
+// itself, and impersonating them explicitly would require permission to impersonate each group.
+func AsServiceAccount[T any](namespace, name string) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	return AsUser[T](fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name))
+}
+
+func impersonationKey(identity rest.ImpersonationConfig) string {
+	groups := slices.Sorted(slices.Values(identity.Groups))
+	extras := make([]string, 0, len(identity.Extra))
+	for k, v := range identity.Extra {
+		extras = append(extras, k+"="+strings.Join(slices.Sorted(slices.Values(v)), ","))
+	}
+	slices.Sort(extras)
+	return strings.Join([]string{
+		identity.UserName,
+		identity.UID,
+		strings.Join(groups, ","),
+		strings.Join(extras, ";"),
+	}, "\x00")
+}
@@ -0,0 +1,137 @@
+package fclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	IOE "github.com/IBM/fp-go/ioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe(
+	"Impersonate", func() {
+
+		var server *httptest.Server
+		var impersonator *fclient.Impersonator
+		var seenUsers []string
+		var seenGroups [][]string
+
+		BeforeEach(
+			func() {
+				seenUsers, seenGroups = nil, nil
+				// The API server allows only "alice" to read configmaps.
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					user := r.Header.Get("Impersonate-User")
+					seenUsers = append(seenUsers, user)
+					seenGroups = append(seenGroups, r.Header.Values("Impersonate-Group"))
+					w.Header().Set("Content-Type", "application/json")
+					if user != "alice" {
+						status := apierrors.NewForbidden(corev1.Resource("configmaps"), "my-config", nil).Status()
+						status.APIVersion, status.Kind = "v1", "Status"
+						w.WriteHeader(http.StatusForbidden)
+						_ = json.NewEncoder(w).Encode(status)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(corev1.ConfigMap{
+						TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+						ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "default"},
+					})
+				}))
+				DeferCleanup(server.Close)
+
+				scheme := runtime.NewScheme()
+				Expect(corev1.AddToScheme(scheme)).To(Succeed())
+				mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+				mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+				impersonator = fclient.NewImpersonator(&rest.Config{Host: server.URL}, client.Options{Scheme: scheme, Mapper: mapper})
+			},
+		)
+
+		getParams := fclient.ToGetParams(client.ObjectKey{Name: "my-config", Namespace: "default"})
+
+		It(
+			"should run the sub-pipeline as the given user", func() {
+				env := fclient.Env{Ctx: context.TODO(), Impersonator: impersonator}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.AsUser[*corev1.ConfigMap]("alice", "developers"),
+				)(env)()
+				cm, err := ET.UnwrapError(result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cm.Name).To(Equal("my-config"))
+				Expect(seenUsers).To(Equal([]string{"alice"}))
+			},
+		)
+
+		It(
+			"should surface authorization failures as Forbidden", func() {
+				env := fclient.Env{Ctx: context.TODO(), Impersonator: impersonator}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.AsServiceAccount[*corev1.ConfigMap]("tenant-a", "builder"),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(apierrors.IsForbidden(err)).To(BeTrue())
+				Expect(seenUsers).To(Equal([]string{"system:serviceaccount:tenant-a:builder"}))
+				Expect(seenGroups).To(ConsistOf(BeEmpty()), "the API server adds the groups of service accounts itself")
+			},
+		)
+
+		It(
+			"should cache clients per identity", func() {
+				alice := rest.ImpersonationConfig{UserName: "alice", Groups: []string{"a", "b"}}
+				aliceAgain := rest.ImpersonationConfig{UserName: "alice", Groups: []string{"b", "a"}}
+				bob := rest.ImpersonationConfig{UserName: "bob"}
+				c1, err := impersonator.ClientFor(alice)
+				Expect(err).NotTo(HaveOccurred())
+				c2, err := impersonator.ClientFor(aliceAgain)
+				Expect(err).NotTo(HaveOccurred())
+				c3, err := impersonator.ClientFor(bob)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c1).To(BeIdenticalTo(c2))
+				Expect(c1).NotTo(BeIdenticalTo(c3))
+			},
+		)
+
+		It(
+			"should build the impersonated client only when the pipeline runs", func() {
+				var applied bool
+				sub := func(env fclient.Env) fclient.IOEither[fclient.Unit] {
+					applied = true
+					return IOE.Right[error](fclient.UnitValue)
+				}
+				env := fclient.Env{Ctx: context.TODO(), Impersonator: impersonator}
+				io := fclient.AsUser[fclient.Unit]("alice")(sub)(env)
+				Expect(applied).To(BeFalse())
+				Expect(ET.IsRight(io())).To(BeTrue())
+				Expect(applied).To(BeTrue())
+			},
+		)
+
+		It(
+			"should fail without an impersonator", func() {
+				env := fclient.Env{Ctx: context.TODO()}
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.AsUser[*corev1.ConfigMap]("alice"),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(err).To(MatchError(fclient.ErrNoImpersonator))
+			},
+		)
+	},
+)
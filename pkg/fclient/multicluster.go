@@ -0,0 +1,88 @@
+package fclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	IOE "github.com/IBM/fp-go/ioeither"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MultiReaderIOEither is a type alias for ReaderIOEither with MultiEnv and error types.
+type MultiReaderIOEither[T any] = RIOE.ReaderIOEither[MultiEnv, error, T]
+
+// MultiEnv represents the environment containing context and named clients for operations spanning several clusters.
+type MultiEnv struct {
+	Ctx      context.Context
+	Clusters map[string]client.Client
+	// MaxConcurrency bounds how many clusters [AcrossClusters] visits at once. Zero or less means no bound.
+	MaxConcurrency int
+}
+
+// Env returns the single-cluster Env for the named cluster.
+func (m MultiEnv) Env(name string) (Env, bool) {
+	cl, ok := m.Clusters[name]
+	if !ok {
+		return Env{}, false
+	}
+	return Env{Ctx: m.Ctx, Client: cl}, true
+}
+
+// ClusterNotFoundError reports that a cluster name is not part of the MultiEnv.
+type ClusterNotFoundError struct {
+	Name string
+}
+
+// Error implements the error interface.
+func (e *ClusterNotFoundError) Error() string {
+	return fmt.Sprintf("cluster %q not found", e.Name)
+}
+
+// OnCluster runs a single-cluster ReaderIOEither against the named cluster.
+//
+// It fails with a [ClusterNotFoundError] when the cluster is not part of the MultiEnv.
+func OnCluster[T any](name string, op ReaderIOEither[T]) MultiReaderIOEither[T] {
+	return func(m MultiEnv) IOEither[T] {
+		env, ok := m.Env(name)
+		if !ok {
+			return IOE.Left[T](error(&ClusterNotFoundError{Name: name}))
+		}
+		return op(env)
+	}
+}
+
+// AcrossClusters runs a single-cluster ReaderIOEither against every cluster of the MultiEnv.
+//
+// Clusters are visited concurrently, bounded by MultiEnv.MaxConcurrency. The result maps each cluster name
+// to the Either produced on that cluster, so a failure on one cluster does not hide the results of the others.
+func AcrossClusters[T any](op ReaderIOEither[T]) MultiReaderIOEither[map[string]Either[T]] {
+	return func(m MultiEnv) IOEither[map[string]Either[T]] {
+		return IOE.FromIO[error](func() map[string]Either[T] {
+			limit := m.MaxConcurrency
+			if limit <= 0 {
+				limit = max(len(m.Clusters), 1)
+			}
+			sem := make(chan struct{}, limit)
+			results := make(map[string]Either[T], len(m.Clusters))
+			var mu sync.Mutex
+			var wg sync.WaitGroup
+			for name := range m.Clusters {
+				env, _ := m.Env(name)
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					result := op(env)()
+					mu.Lock()
+					defer mu.Unlock()
+					results[name] = result
+				}()
+			}
+			wg.Wait()
+			return results
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package fclient_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe(
+	"MultiEnv", func() {
+
+		var inFlight, peak atomic.Int32
+
+		// clusterWith returns a fake cluster holding the named configmaps that records concurrent Lists.
+		clusterWith := func(names ...string) client.Client {
+			objs := make([]client.Object, 0, len(names))
+			for _, name := range names {
+				objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+			}
+			return fake.NewClientBuilder().
+				WithObjects(objs...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						n := inFlight.Add(1)
+						defer inFlight.Add(-1)
+						for {
+							p := peak.Load()
+							if n <= p || peak.CompareAndSwap(p, n) {
+								break
+							}
+						}
+						time.Sleep(5 * time.Millisecond)
+						return c.List(ctx, list, opts...)
+					},
+				}).
+				Build()
+		}
+
+		var multiEnv fclient.MultiEnv
+
+		BeforeEach(
+			func() {
+				inFlight.Store(0)
+				peak.Store(0)
+				multiEnv = fclient.MultiEnv{
+					Ctx: context.TODO(),
+					Clusters: map[string]client.Client{
+						"east":  clusterWith("a", "b"),
+						"west":  clusterWith("c"),
+						"north": clusterWith(),
+					},
+				}
+			},
+		)
+
+		listItems := fclient.ListItems[corev1.ConfigMap, corev1.ConfigMapList](fclient.ToListParams(client.InNamespace("default")))
+
+		It(
+			"should run an operation on the named cluster", func() {
+				params := fclient.ToGetParams(client.ObjectKey{Name: "c", Namespace: "default"})
+				result := fclient.OnCluster("west", fclient.Get[corev1.ConfigMap](params))(multiEnv)()
+				cm, err := ET.UnwrapError(result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cm.Name).To(Equal("c"))
+
+				result = fclient.OnCluster("east", fclient.Get[corev1.ConfigMap](params))(multiEnv)()
+				_, err = ET.UnwrapError(result)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should fail on an unknown cluster", func() {
+				result := fclient.OnCluster("south", listItems)(multiEnv)()
+				_, err := ET.UnwrapError(result)
+				var notFound *fclient.ClusterNotFoundError
+				Expect(err).To(BeAssignableToTypeOf(notFound))
+			},
+		)
+
+		It(
+			"should fan out to every cluster", func() {
+				result := fclient.AcrossClusters(listItems)(multiEnv)()
+				perCluster, err := ET.UnwrapError(result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(perCluster).To(HaveLen(3))
+				counts := map[string]int{}
+				for name, r := range perCluster {
+					items, err := ET.UnwrapError(r)
+					Expect(err).NotTo(HaveOccurred())
+					counts[name] = len(items)
+				}
+				Expect(counts).To(Equal(map[string]int{"east": 2, "west": 1, "north": 0}))
+			},
+		)
+
+		It(
+			"should bound the concurrency", func() {
+				multiEnv.MaxConcurrency = 1
+				result := fclient.AcrossClusters(listItems)(multiEnv)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(peak.Load()).To(Equal(int32(1)))
+			},
+		)
+	},
+)
@@ -0,0 +1,175 @@
+package fclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterScopedError reports that a cluster-scoped type was used inside [InNamespace].
+type ClusterScopedError struct {
+	Kind string
+}
+
+// Error implements the error interface.
+func (e *ClusterScopedError) Error() string {
+	return fmt.Sprintf("%s is cluster-scoped and cannot be used in a namespace-bound environment", e.Kind)
+}
+
+// NamespaceMismatchError reports that a namespace other than the bound one was supplied inside [InNamespace].
+type NamespaceMismatchError struct {
+	Namespace string
+	Expected  string
+}
+
+// Error implements the error interface.
+func (e *NamespaceMismatchError) Error() string {
+	return fmt.Sprintf("namespace %q does not match the bound namespace %q", e.Namespace, e.Expected)
+}
+
+// InNamespace runs a sub-pipeline with an Env whose client is bound to the given namespace.
+//
+// Inside the sub-pipeline, keys and objects without a namespace are defaulted to the bound namespace,
+// so name-only parameters such as [ToNamedGetParams] can be used. Operations fail fast with
+// a [ClusterScopedError] when a cluster-scoped type is used, and with a [NamespaceMismatchError]
+// when another namespace is supplied explicitly.
+func InNamespace[T any](namespace string, op ReaderIOEither[T]) ReaderIOEither[T] {
+	return func(env Env) IOEither[T] {
+		bound := env
+		bound.Client = &namespaceBoundClient{
+			Client:    client.NewNamespacedClient(env.Client, namespace),
+			namespace: namespace,
+		}
+		return op(bound)
+	}
+}
+
+// ToNamedGetParams creates GetParams from a name only, for use inside [InNamespace].
+func ToNamedGetParams(name string, opts ...client.GetOption) GetParams {
+	return ToGetParams(client.ObjectKey{Name: name}, opts...)
+}
+
+// namespaceBoundClient validates scope and namespace before delegating to a namespaced client.
+type namespaceBoundClient struct {
+	client.Client
+	namespace string
+}
+
+func (c *namespaceBoundClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.validate(obj, key.Namespace); err != nil {
+		return err
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *namespaceBoundClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	if err := c.validate(list, listOpts.Namespace); err != nil {
+		return err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *namespaceBoundClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *namespaceBoundClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *namespaceBoundClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *namespaceBoundClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *namespaceBoundClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteOpts := (&client.DeleteAllOfOptions{}).ApplyOptions(opts)
+	if err := c.validate(obj, deleteOpts.Namespace); err != nil {
+		return err
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *namespaceBoundClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *namespaceBoundClient) SubResource(subResource string) client.SubResourceClient {
+	return &namespaceBoundSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), parent: c}
+}
+
+// validate checks that obj is namespace-scoped and that namespace, when set, matches the bound namespace.
+func (c *namespaceBoundClient) validate(obj runtime.Object, namespace string) error {
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return fmt.Errorf("error finding the kind of the object: %w", err)
+	}
+	if apimeta.IsListType(obj) {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("error finding the scope of the object: %w", err)
+	}
+	if mapping.Scope.Name() != apimeta.RESTScopeNameNamespace {
+		return &ClusterScopedError{Kind: gvk.Kind}
+	}
+	if namespace != "" && namespace != c.namespace {
+		return &NamespaceMismatchError{Namespace: namespace, Expected: c.namespace}
+	}
+	return nil
+}
+
+// namespaceBoundSubResourceClient applies the same validation as namespaceBoundClient to subresources.
+type namespaceBoundSubResourceClient struct {
+	client.SubResourceClient
+	parent *namespaceBoundClient
+}
+
+func (c *namespaceBoundSubResourceClient) Get(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	if err := c.parent.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *namespaceBoundSubResourceClient) Create(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if err := c.parent.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *namespaceBoundSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if err := c.parent.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *namespaceBoundSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := c.parent.validate(obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
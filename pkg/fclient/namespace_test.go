@@ -0,0 +1,103 @@
+package fclient_test
+
+import (
+	"context"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe(
+	"InNamespace", func() {
+
+		var env fclient.Env
+
+		BeforeEach(
+			func() {
+				cl := fake.NewClientBuilder().
+					WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)).
+					WithObjects(
+						&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config1", Namespace: "team-a"}},
+						&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config2", Namespace: "team-a"}},
+						&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config1", Namespace: "team-b"}},
+					).
+					Build()
+				env = fclient.Env{Ctx: context.TODO(), Client: cl}
+			},
+		)
+
+		It(
+			"should get objects by name in the bound namespace", func() {
+				result := fclient.InNamespace("team-b", fclient.Get[corev1.ConfigMap](fclient.ToNamedGetParams("config1")))(env)()
+				cm, err := ET.UnwrapError(result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cm.Namespace).To(Equal("team-b"))
+			},
+		)
+
+		It(
+			"should list objects in the bound namespace", func() {
+				result := fclient.InNamespace("team-a", fclient.ListItems[corev1.ConfigMap, corev1.ConfigMapList](fclient.ToListParams()))(env)()
+				items, err := ET.UnwrapError(result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(items).To(HaveLen(2))
+			},
+		)
+
+		It(
+			"should default the namespace of created objects", func() {
+				cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config3"}}
+				result := fclient.InNamespace("team-b", fclient.Create(fclient.ToCreateParams(cm)))(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(cm.Namespace).To(Equal("team-b"))
+			},
+		)
+
+		It(
+			"should delete all objects in the bound namespace only", func() {
+				result := fclient.InNamespace("team-a", fclient.DeleteAllOf[corev1.ConfigMap](fclient.ToDeleteAllOfParams()))(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				remaining, err := ET.UnwrapError(fclient.ListItems[corev1.ConfigMap, corev1.ConfigMapList](fclient.ToListParams())(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(HaveLen(1))
+				Expect(remaining[0].Namespace).To(Equal("team-b"))
+			},
+		)
+
+		It(
+			"should reject cluster-scoped types", func() {
+				result := fclient.InNamespace("team-a", fclient.Get[corev1.Namespace](fclient.ToNamedGetParams("team-a")))(env)()
+				_, err := ET.UnwrapError(result)
+				var scopeErr *fclient.ClusterScopedError
+				Expect(err).To(BeAssignableToTypeOf(scopeErr))
+			},
+		)
+
+		It(
+			"should reject mismatched namespaces", func() {
+				getParams := fclient.ToGetParams(client.ObjectKey{Name: "config1", Namespace: "team-b"})
+				result := fclient.InNamespace("team-a", fclient.Get[corev1.ConfigMap](getParams))(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(err).To(MatchError(&fclient.NamespaceMismatchError{Namespace: "team-b", Expected: "team-a"}))
+
+				listParams := fclient.ToListParams(client.InNamespace("team-b"))
+				listResult := fclient.InNamespace("team-a", fclient.List[corev1.ConfigMapList](listParams))(env)()
+				_, err = ET.UnwrapError(listResult)
+				Expect(err).To(MatchError(&fclient.NamespaceMismatchError{Namespace: "team-b", Expected: "team-a"}))
+
+				cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config1", Namespace: "team-b"}}
+				statusResult := fclient.InNamespace("team-a", fclient.StatusUpdate(fclient.ToStatusUpdateParams(cm)))(env)()
+				_, err = ET.UnwrapError(statusResult)
+				Expect(err).To(MatchError(&fclient.NamespaceMismatchError{Namespace: "team-b", Expected: "team-a"}))
+			},
+		)
+	},
+)
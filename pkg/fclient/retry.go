@@ -0,0 +1,128 @@
+package fclient
+
+import (
+	"math/rand/v2"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// RetryPolicy configures the exponential backoff used by [Retry].
+type RetryPolicy struct {
+	// InitialInterval is the delay before the first retry. Zero means the 100ms of [DefaultRetryPolicy],
+	// so that a zero RetryPolicy never retries in a tight loop.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay computed by the backoff. Zero means no cap.
+	MaxInterval time.Duration
+	// Multiplier is the factor by which the delay grows after every retry.
+	Multiplier float64
+	// Jitter randomizes each delay by up to ±Jitter of its value. It should be within [0, 1].
+	Jitter float64
+	// MaxElapsedTime bounds the total time spent, including delays. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// IsRetryable decides whether an error is worth retrying. Nil means [IsTransient].
+	IsRetryable func(error) bool
+}
+
+// defaultInitialInterval is the InitialInterval of [DefaultRetryPolicy], also used when a policy leaves it at zero.
+const defaultInitialInterval = 100 * time.Millisecond
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for transient Kubernetes API errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		InitialInterval: defaultInitialInterval,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxElapsedTime:  time.Minute,
+		IsRetryable:     IsTransient,
+	}
+}
+
+// IsTransient reports whether err is a Kubernetes API error that is likely to succeed when retried.
+//
+// It matches throttling (429), server timeouts, internal and unavailable server errors,
+// errors carrying a Retry-After hint, and dropped or refused connections.
+// Conflicts are not considered transient since retrying them requires re-reading the object.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := apierrors.SuggestsClientDelay(err); ok {
+		return true
+	}
+	return apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsUnexpectedServerError(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsHTTP2ConnectionLost(err) ||
+		utilnet.IsProbableEOF(err)
+}
+
+// Retry re-runs a ReaderIOEither with exponential backoff while it fails with a retryable error.
+//
+// The delay before each retry follows the policy's backoff with jitter, unless the error carries
+// a Retry-After hint from the server (see [apierrors.SuggestsClientDelay]), in which case the hint is used.
+// Retrying stops with the last error when the error is not retryable, when the next delay would exceed
+// the policy's MaxElapsedTime, or when Env.Ctx is done.
+func Retry[T any](policy RetryPolicy) func(ReaderIOEither[T]) ReaderIOEither[T] {
+	isRetryable := policy.IsRetryable
+	if isRetryable == nil {
+		isRetryable = IsTransient
+	}
+	if policy.InitialInterval <= 0 {
+		policy.InitialInterval = defaultInitialInterval
+	}
+	return func(rioe ReaderIOEither[T]) ReaderIOEither[T] {
+		return func(env Env) IOEither[T] {
+			return func() Either[T] {
+				start := time.Now()
+				interval := policy.InitialInterval
+				for {
+					result := rioe(env)()
+					err := ET.ToError(result)
+					if err == nil || !isRetryable(err) {
+						return result
+					}
+					delay := policy.jitter(interval)
+					if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+						delay = time.Duration(seconds) * time.Second
+					}
+					if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+						return result
+					}
+					timer := time.NewTimer(delay)
+					select {
+					case <-env.Ctx.Done():
+						timer.Stop()
+						return result
+					case <-timer.C:
+					}
+					interval = policy.next(interval)
+				}
+			}
+		}
+	}
+}
+
+func (p RetryPolicy) next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * max(p.Multiplier, 1))
+	if p.MaxInterval > 0 && next > p.MaxInterval {
+		return p.MaxInterval
+	}
+	return next
+}
+
+func (p RetryPolicy) jitter(interval time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return interval
+	}
+	delta := p.Jitter * float64(interval)
+	return time.Duration(float64(interval) - delta + rand.Float64()*2*delta)
+}
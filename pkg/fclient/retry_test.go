@@ -0,0 +1,173 @@
+package fclient_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	"github.com/appthrust/fcr/pkg/fclient"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe(
+	"Retry", func() {
+
+		var attempts int
+
+		// flakyEnv returns an Env whose Get fails with the given errors before delegating to the fake client.
+		flakyEnv := func(errs ...error) fclient.Env {
+			attempts = 0
+			cl := fake.NewClientBuilder().
+				WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "default"}}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						attempts++
+						if attempts <= len(errs) {
+							return errs[attempts-1]
+						}
+						return c.Get(ctx, key, obj, opts...)
+					},
+				}).
+				Build()
+			return fclient.Env{Ctx: context.TODO(), Client: cl}
+		}
+
+		policy := fclient.RetryPolicy{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     5 * time.Millisecond,
+			Multiplier:      2,
+			Jitter:          0.5,
+			MaxElapsedTime:  time.Second,
+		}
+
+		getParams := fclient.ToGetParams(client.ObjectKey{Name: "my-config", Namespace: "default"})
+
+		It(
+			"should retry transient errors until success", func() {
+				env := flakyEnv(
+					apierrors.NewTooManyRequests("slow down", 0),
+					apierrors.NewInternalError(errors.New("boom")),
+					apierrors.NewServiceUnavailable("unavailable"),
+					syscall.ECONNRESET,
+				)
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](policy),
+				)(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(attempts).To(Equal(5))
+			},
+		)
+
+		It(
+			"should not retry non-transient errors", func() {
+				env := flakyEnv(apierrors.NewConflict(corev1.Resource("configmaps"), "my-config", errors.New("conflict")))
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](policy),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(apierrors.IsConflict(err)).To(BeTrue())
+				Expect(attempts).To(Equal(1))
+			},
+		)
+
+		It(
+			"should use a custom classifier", func() {
+				conflict := apierrors.NewConflict(corev1.Resource("configmaps"), "my-config", errors.New("conflict"))
+				env := flakyEnv(conflict, conflict)
+				custom := policy
+				custom.IsRetryable = apierrors.IsConflict
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](custom),
+				)(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(attempts).To(Equal(3))
+			},
+		)
+
+		It(
+			"should give up after the max elapsed time", func() {
+				errs := make([]error, 1000)
+				for i := range errs {
+					errs[i] = apierrors.NewTooManyRequests("slow down", 0)
+				}
+				env := flakyEnv(errs...)
+				limited := policy
+				limited.MaxElapsedTime = 20 * time.Millisecond
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](limited),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(apierrors.IsTooManyRequests(err)).To(BeTrue())
+				Expect(attempts).To(BeNumerically(">", 1))
+				Expect(attempts).To(BeNumerically("<", len(errs)))
+			},
+		)
+
+		It(
+			"should not retry in a tight loop with a zero policy", func() {
+				errs := make([]error, 1000)
+				for i := range errs {
+					errs[i] = apierrors.NewTooManyRequests("slow down", 0)
+				}
+				env := flakyEnv(errs...)
+				ctx, cancel := context.WithTimeout(context.TODO(), 250*time.Millisecond)
+				defer cancel()
+				env.Ctx = ctx
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](fclient.RetryPolicy{}),
+				)(env)()
+				Expect(ET.IsLeft(result)).To(BeTrue())
+				Expect(attempts).To(BeNumerically(">", 1))
+				Expect(attempts).To(BeNumerically("<=", 4))
+			},
+		)
+
+		It(
+			"should honour the Retry-After hint", func() {
+				env := flakyEnv(apierrors.NewTooManyRequests("slow down", 1))
+				patient := policy
+				patient.MaxElapsedTime = 5 * time.Second
+				start := time.Now()
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](patient),
+				)(env)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+				Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+			},
+		)
+
+		It(
+			"should stop when the context is done", func() {
+				env := flakyEnv(apierrors.NewTooManyRequests("slow down", 30))
+				ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+				defer cancel()
+				env.Ctx = ctx
+				unlimited := policy
+				unlimited.MaxElapsedTime = 0
+				result := F.Pipe1(
+					fclient.Get[corev1.ConfigMap](getParams),
+					fclient.Retry[*corev1.ConfigMap](unlimited),
+				)(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(apierrors.IsTooManyRequests(err)).To(BeTrue())
+				Expect(attempts).To(Equal(1))
+			},
+		)
+	},
+)
@@ -0,0 +1,118 @@
+// Package fhandler provides controller-runtime event handlers whose map functions are [fclient] pipelines.
+package fhandler
+
+import (
+	"context"
+	"fmt"
+
+	A "github.com/IBM/fp-go/array"
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapErrors counts the map functions of [MapTo] that failed, by object type.
+var mapErrors = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fcr_handler_map_errors_total",
+		Help: "Number of event map functions that failed, by object type.",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(mapErrors)
+}
+
+// MapFunc maps an event on an object of type OP to the requests to reconcile.
+type MapFunc[OP any] = func(OP) fclient.ReaderIOEither[[]reconcile.Request]
+
+// Handler builds an event handler once the Env of its controller is known, e.g. from fmanager.EnvFor.
+type Handler = func(env func(context.Context) fclient.Env) handler.EventHandler
+
+// MapTo is the Handler enqueuing the requests computed by f for events on objects of type OP.
+//
+// f runs in the Env built for the event's context. When it fails, the error is logged, counted in the
+// fcr_handler_map_errors_total metric, and nothing is enqueued.
+func MapTo[OP client.Object](f MapFunc[OP]) Handler {
+	return func(env func(context.Context) fclient.Env) handler.EventHandler {
+		return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			typed, ok := obj.(OP)
+			if !ok {
+				return nil
+			}
+			requests, err := ET.UnwrapError(f(typed)(env(ctx))())
+			if err != nil {
+				mapErrors.WithLabelValues(fmt.Sprintf("%T", obj)).Inc()
+				log.FromContext(ctx).Error(err, "Failed to map event to requests", "object", client.ObjectKeyFromObject(obj))
+				return nil
+			}
+			return requests
+		})
+	}
+}
+
+// ToOwners maps an object to its owners of type O, found in its owner references.
+func ToOwners[O any, S client.Object, OP fclient.ObjectPointer[O]]() MapFunc[S] {
+	return func(obj S) fclient.ReaderIOEither[[]reconcile.Request] {
+		return func(env fclient.Env) fclient.IOEither[[]reconcile.Request] {
+			return func() fclient.Either[[]reconcile.Request] {
+				gvk, err := apiutil.GVKForObject(OP(new(O)), env.Client.Scheme())
+				if err != nil {
+					return ET.Left[[]reconcile.Request](err)
+				}
+				var requests []reconcile.Request
+				for _, ref := range obj.GetOwnerReferences() {
+					if ref.Kind != gvk.Kind {
+						continue
+					}
+					if refGV, err := schema.ParseGroupVersion(ref.APIVersion); err != nil || refGV.Group != gvk.Group {
+						continue
+					}
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: ref.Name, Namespace: obj.GetNamespace()}})
+				}
+				return ET.Right[error](requests)
+			}
+		}
+	}
+}
+
+// ByIndex maps an object to the objects of type T whose field index field holds value(obj).
+//
+// The lookup is limited to the object's namespace unless the object is cluster-scoped.
+func ByIndex[T any, TL any, S client.Object, OP fclient.ObjectPointer[T], OLP fclient.ObjectListPointer[TL]](field string, value func(S) string) MapFunc[S] {
+	return func(obj S) fclient.ReaderIOEither[[]reconcile.Request] {
+		return listRequests[T, TL, OP, OLP](obj, client.MatchingFields{field: value(obj)})
+	}
+}
+
+// BySelector maps an object to the objects of type T matching the label selector computed from it.
+//
+// The lookup is limited to the object's namespace unless the object is cluster-scoped.
+func BySelector[T any, TL any, S client.Object, OP fclient.ObjectPointer[T], OLP fclient.ObjectListPointer[TL]](selector func(S) labels.Selector) MapFunc[S] {
+	return func(obj S) fclient.ReaderIOEither[[]reconcile.Request] {
+		return listRequests[T, TL, OP, OLP](obj, client.MatchingLabelsSelector{Selector: selector(obj)})
+	}
+}
+
+func listRequests[T any, TL any, OP fclient.ObjectPointer[T], OLP fclient.ObjectListPointer[TL]](obj client.Object, opts ...client.ListOption) fclient.ReaderIOEither[[]reconcile.Request] {
+	if obj.GetNamespace() != "" {
+		opts = append(opts, client.InNamespace(obj.GetNamespace()))
+	}
+	return F.Pipe1(
+		fclient.ListItems[T, TL, OP, OLP](fclient.ToListParams(opts...)),
+		RIOE.Map[fclient.Env, error](A.Map(func(item OP) reconcile.Request {
+			return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(item)}
+		})),
+	)
+}
@@ -0,0 +1,159 @@
+package fhandler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ET "github.com/IBM/fp-go/either"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fhandler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFhandler(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fhandler Suite")
+}
+
+const secretIndex = "metadata.annotations.secret"
+
+// newFakeClient returns a fake client aware of the test API types, indexing cats by their secret annotation.
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&v1.Cat{}, secretIndex, func(obj client.Object) []string {
+			return []string{obj.GetAnnotations()["secret"]}
+		}).
+		Build()
+}
+
+func cat(name string, annotations, lbls map[string]string) *v1.Cat {
+	return &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations, Labels: lbls}}
+}
+
+func request(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+}
+
+var _ = Describe(
+	"MapTo", func() {
+
+		var queue workqueue.TypedRateLimitingInterface[reconcile.Request]
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "food", Namespace: "default"}}
+
+		BeforeEach(
+			func() {
+				queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+				DeferCleanup(queue.ShutDown)
+			},
+		)
+
+		envFor := func(cl client.Client) func(context.Context) fclient.Env {
+			return func(ctx context.Context) fclient.Env {
+				return fclient.Env{Ctx: ctx, Client: cl}
+			}
+		}
+
+		It(
+			"should enqueue the requests computed in the Env", func() {
+				cl := newFakeClient(cat("tom", map[string]string{"secret": "food"}, nil))
+				h := fhandler.MapTo(fhandler.ByIndex[v1.Cat, v1.CatList](secretIndex, func(s *corev1.Secret) string { return s.Name }))(envFor(cl))
+				h.Create(context.TODO(), event.CreateEvent{Object: secret}, queue)
+				Expect(queue.Len()).To(Equal(1))
+				item, _ := queue.Get()
+				Expect(item).To(Equal(request("tom")))
+			},
+		)
+
+		It(
+			"should enqueue nothing when the map function fails", func() {
+				h := fhandler.MapTo(func(*corev1.Secret) fclient.ReaderIOEither[[]reconcile.Request] {
+					return RIOE.Left[fclient.Env, []reconcile.Request](errors.New("boom"))
+				})(envFor(newFakeClient()))
+				h.Update(context.TODO(), event.UpdateEvent{ObjectOld: secret, ObjectNew: secret}, queue)
+				Expect(queue.Len()).To(BeZero())
+			},
+		)
+
+		It(
+			"should ignore objects of another type", func() {
+				h := fhandler.MapTo(func(*corev1.Secret) fclient.ReaderIOEither[[]reconcile.Request] {
+					return RIOE.Right[fclient.Env, error]([]reconcile.Request{request("tom")})
+				})(envFor(newFakeClient()))
+				h.Create(context.TODO(), event.CreateEvent{Object: &corev1.ConfigMap{}}, queue)
+				Expect(queue.Len()).To(BeZero())
+			},
+		)
+	},
+)
+
+var _ = Describe(
+	"Mappers", func() {
+
+		run := func(cl client.Client, rioe fclient.ReaderIOEither[[]reconcile.Request]) []reconcile.Request {
+			requests, err := ET.UnwrapError(rioe(fclient.Env{Ctx: context.TODO(), Client: cl})())
+			Expect(err).NotTo(HaveOccurred())
+			return requests
+		}
+
+		It(
+			"should map to the owners of a type", func() {
+				cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Name:      "toys",
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: v1.GroupVersion.String(), Kind: "Cat", Name: "tom", UID: "1"},
+						{APIVersion: "v1", Kind: "Secret", Name: "food", UID: "2"},
+						{APIVersion: "other.example.com/v1", Kind: "Cat", Name: "garfield", UID: "3"},
+					},
+				}}
+				Expect(run(newFakeClient(), fhandler.ToOwners[v1.Cat, *corev1.ConfigMap]()(cm))).To(Equal([]reconcile.Request{request("tom")}))
+			},
+		)
+
+		It(
+			"should map through a field index in the object's namespace", func() {
+				cl := newFakeClient(
+					cat("tom", map[string]string{"secret": "food"}, nil),
+					cat("felix", map[string]string{"secret": "toys"}, nil),
+				)
+				mapper := fhandler.ByIndex[v1.Cat, v1.CatList](secretIndex, func(s *corev1.Secret) string { return s.Name })
+				secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "food", Namespace: "default"}}
+				Expect(run(cl, mapper(secret))).To(Equal([]reconcile.Request{request("tom")}))
+			},
+		)
+
+		It(
+			"should map through a label selector", func() {
+				cl := newFakeClient(
+					cat("tom", nil, map[string]string{"home": "kitchen"}),
+					cat("felix", nil, map[string]string{"home": "garden"}),
+				)
+				mapper := fhandler.BySelector[v1.Cat, v1.CatList](func(cm *corev1.ConfigMap) labels.Selector {
+					return labels.SelectorFromSet(labels.Set{"home": cm.Labels["room"]})
+				})
+				cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "heating", Namespace: "default", Labels: map[string]string{"room": "garden"}}}
+				Expect(run(cl, mapper(cm))).To(Equal([]reconcile.Request{request("felix")}))
+			},
+		)
+	},
+)
@@ -0,0 +1,149 @@
+// Package fmanager provides the lifecycle of a controller-runtime [manager.Manager] as composable effects.
+//
+// A manager is built with [New], set up with [Register] and run with [Run]:
+//
+//	F.Pipe2(
+//		fmanager.New(config, manager.Options{Scheme: scheme}),
+//		IOE.Chain(fmanager.Register(
+//			F.Pipe1(fbuilder.For[v1.Cat](), fbuilder.Register(reconcileCat)),
+//			fmanager.Healthz("ping", healthz.Ping),
+//		)),
+//		IOE.Chain(fmanager.Run(ctx)),
+//	)()
+package fmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ET "github.com/IBM/fp-go/either"
+	IOE "github.com/IBM/fp-go/ioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Setup registers something, such as a controller, an index or a runnable, with a manager.
+type Setup = func(manager.Manager) fclient.IOEither[fclient.Unit]
+
+// New builds a manager for the cluster at config.
+func New(config *rest.Config, opts manager.Options) fclient.IOEither[manager.Manager] {
+	return IOE.TryCatchError(func() (manager.Manager, error) {
+		return manager.New(config, opts)
+	})
+}
+
+// Register runs setups against a manager in order, stopping at the first one that fails.
+func Register(setups ...Setup) func(manager.Manager) fclient.IOEither[manager.Manager] {
+	return func(mgr manager.Manager) fclient.IOEither[manager.Manager] {
+		return func() fclient.Either[manager.Manager] {
+			for _, setup := range setups {
+				if _, err := ET.UnwrapError(setup(mgr)()); err != nil {
+					return ET.Left[manager.Manager](err)
+				}
+			}
+			return ET.Right[error](mgr)
+		}
+	}
+}
+
+// Run starts the manager and blocks until ctx is done.
+//
+// It returns a Left when the manager fails to start or one of its runnables fails, and a Right on graceful shutdown.
+func Run(ctx context.Context) func(manager.Manager) fclient.IOEither[fclient.Unit] {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return func() fclient.Either[fclient.Unit] {
+			return ET.TryCatchError(fclient.UnitValue, mgr.Start(ctx))
+		}
+	}
+}
+
+// Add registers a runnable with the manager.
+func Add(r manager.Runnable) Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return fromError(func() error { return mgr.Add(r) })
+	}
+}
+
+// Index registers a field index on objects of type T, computed by extract.
+func Index[T any, OP fclient.ObjectPointer[T]](field string, extract func(OP) []string) Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return fromError(func() error {
+			return mgr.GetFieldIndexer().IndexField(context.Background(), OP(new(T)), field, func(obj client.Object) []string {
+				typed, ok := obj.(OP)
+				if !ok {
+					return nil
+				}
+				return extract(typed)
+			})
+		})
+	}
+}
+
+// Webhook serves hook at path on the manager's webhook server.
+//
+// Registering a path twice returns a Left, where the webhook server panics.
+func Webhook(path string, hook http.Handler) Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return fromError(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("failed to register webhook at %s: %v", path, r)
+				}
+			}()
+			mgr.GetWebhookServer().Register(path, hook)
+			return nil
+		})
+	}
+}
+
+// Healthz adds a liveness check to the manager.
+func Healthz(name string, check healthz.Checker) Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return fromError(func() error { return mgr.AddHealthzCheck(name, check) })
+	}
+}
+
+// Readyz adds a readiness check to the manager.
+func Readyz(name string, check healthz.Checker) Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return fromError(func() error { return mgr.AddReadyzCheck(name, check) })
+	}
+}
+
+// EnvFor returns how to build the Env of the component called name from a request context.
+//
+// The Env holds the manager's client, whose Scheme is the manager's scheme and whose reads are served by the cache,
+// its cache, its API reader for live reads, an event recorder for name, and a context carrying the manager's logger
+// named after name, unless ctx already carries a logger.
+func EnvFor(mgr manager.Manager, name string) func(context.Context) fclient.Env {
+	recorder := mgr.GetEventRecorderFor(name)
+	logger := mgr.GetLogger().WithName(name)
+	return func(ctx context.Context) fclient.Env {
+		if _, err := logr.FromContext(ctx); err != nil {
+			ctx = log.IntoContext(ctx, logger)
+		}
+		return fclient.Env{Ctx: ctx, Client: mgr.GetClient(), Recorder: recorder, Cache: mgr.GetCache(), APIReader: mgr.GetAPIReader()}
+	}
+}
+
+// Reconciler adapts a functional reconciler to [reconcile.Reconciler], evaluating it with the Env of [EnvFor].
+func Reconciler(mgr manager.Manager, name string, f freconcile.Reconciler) reconcile.Reconciler {
+	env := EnvFor(mgr, name)
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return ET.UnwrapError(f(req)(env(ctx))())
+	})
+}
+
+func fromError(f func() error) fclient.IOEither[fclient.Unit] {
+	return IOE.TryCatchError(func() (fclient.Unit, error) {
+		return fclient.UnitValue, f()
+	})
+}
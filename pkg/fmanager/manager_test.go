@@ -0,0 +1,163 @@
+package fmanager_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	IOE "github.com/IBM/fp-go/ioeither"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFmanager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fmanager Suite")
+}
+
+// newManager builds a manager for a cluster that is never contacted, aware of the test API types.
+func newManager() fclient.IOEither[manager.Manager] {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	return fmanager.New(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		MapperProvider: func(*rest.Config, *http.Client) (apimeta.RESTMapper, error) {
+			mapper := apimeta.NewDefaultRESTMapper(nil)
+			for gvk := range scheme.AllKnownTypes() {
+				mapper.Add(gvk, apimeta.RESTScopeNamespace)
+			}
+			return mapper, nil
+		},
+	})
+}
+
+var _ = Describe(
+	"Manager", func() {
+
+		It(
+			"should return a Left when the manager cannot be built", func() {
+				result := fmanager.New(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+					HealthProbeBindAddress: "not an address",
+				})()
+				Expect(ET.IsLeft(result)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should register setups in order and stop at the first failure", func() {
+				var ran []string
+				step := func(name string, err error) fmanager.Setup {
+					return func(manager.Manager) fclient.IOEither[fclient.Unit] {
+						return func() fclient.Either[fclient.Unit] {
+							ran = append(ran, name)
+							return ET.TryCatchError(fclient.UnitValue, err)
+						}
+					}
+				}
+				boom := errors.New("boom")
+				_, err := ET.UnwrapError(F.Pipe1(
+					newManager(),
+					IOE.Chain(fmanager.Register(step("a", nil), step("b", boom), step("c", nil))),
+				)())
+				Expect(err).To(MatchError(boom))
+				Expect(ran).To(Equal([]string{"a", "b"}))
+			},
+		)
+
+		It(
+			"should register indexes, webhooks and health checks", func() {
+				result := F.Pipe1(
+					newManager(),
+					IOE.Chain(fmanager.Register(
+						fmanager.Index("spec.owner", func(cm *corev1.ConfigMap) []string { return []string{cm.Labels["owner"]} }),
+						fmanager.Webhook("/validate", http.NotFoundHandler()),
+						fmanager.Healthz("ping", healthz.Ping),
+						fmanager.Readyz("ping", healthz.Ping),
+					)),
+				)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should return a Left when a webhook path is registered twice", func() {
+				_, err := ET.UnwrapError(F.Pipe1(
+					newManager(),
+					IOE.Chain(fmanager.Register(
+						fmanager.Webhook("/validate", http.NotFoundHandler()),
+						fmanager.Webhook("/validate", http.NotFoundHandler()),
+					)),
+				)())
+				Expect(err).To(MatchError(ContainSubstring("failed to register webhook at /validate")))
+			},
+		)
+
+		It(
+			"should return a Left when a runnable fails and a Right on graceful shutdown", func() {
+				boom := errors.New("runnable failed")
+				_, err := ET.UnwrapError(F.Pipe2(
+					newManager(),
+					IOE.Chain(fmanager.Register(fmanager.Add(manager.RunnableFunc(func(context.Context) error { return boom })))),
+					IOE.Chain(fmanager.Run(context.TODO())),
+				)())
+				Expect(err).To(MatchError(boom))
+
+				ctx, cancel := context.WithCancel(context.TODO())
+				result := F.Pipe2(
+					newManager(),
+					IOE.Chain(fmanager.Register(fmanager.Add(manager.RunnableFunc(func(context.Context) error {
+						cancel()
+						return nil
+					})))),
+					IOE.Chain(fmanager.Run(ctx)),
+				)()
+				Expect(ET.IsRight(result)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should derive the Env of reconcilers from the manager", func() {
+				mgr, err := ET.UnwrapError(newManager()())
+				Expect(err).NotTo(HaveOccurred())
+				var seen fclient.Env
+				r := fmanager.Reconciler(mgr, "cat", func(reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+					return func(env fclient.Env) fclient.IOEither[reconcile.Result] {
+						seen = env
+						return RIOE.Right[fclient.Env, error](reconcile.Result{})(env)
+					}
+				})
+				_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(seen.Client).To(BeIdenticalTo(mgr.GetClient()))
+				Expect(seen.Recorder).NotTo(BeNil())
+				Expect(seen.Cache).To(BeIdenticalTo(mgr.GetCache()))
+				Expect(seen.APIReader).To(BeIdenticalTo(mgr.GetAPIReader()))
+				_, err = logr.FromContext(seen.Ctx)
+				Expect(err).NotTo(HaveOccurred())
+				gvk, err := apiutil.GVKForObject(&v1.Cat{}, seen.Client.Scheme())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gvk.Kind).To(Equal("Cat"))
+			},
+		)
+	},
+)
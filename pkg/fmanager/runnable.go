@@ -0,0 +1,97 @@
+package fmanager
+
+import (
+	"context"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// RunnableOptions configures a [Runnable].
+type RunnableOptions struct {
+	// Name names the runnable in logs and events. It defaults to "runnable".
+	Name string
+	// Interval runs the pipeline periodically, waiting Interval after each success. Zero runs it once.
+	Interval time.Duration
+	// NeedLeaderElection runs the pipeline only on the elected leader.
+	NeedLeaderElection bool
+	// Backoff restarts the pipeline after a failure. Its zero fields are taken from [fclient.DefaultRetryPolicy],
+	// except MaxElapsedTime, which stays unlimited, Jitter, which is used as given, and a nil IsRetryable,
+	// which retries every error.
+	// When the backoff gives up, the error is returned to the manager, which then stops.
+	Backoff fclient.RetryPolicy
+}
+
+// Runnable is the Setup adding a background loop running op to the manager.
+//
+// op runs with the Env built by [EnvFor] for the runnable's name, once or every Interval as configured,
+// and is restarted with backoff when it fails. The loop stops cleanly when the manager's context ends.
+func Runnable(op fclient.ReaderIOEither[fclient.Unit], opts RunnableOptions) Setup {
+	if opts.Name == "" {
+		opts.Name = "runnable"
+	}
+	defaults := fclient.DefaultRetryPolicy()
+	if opts.Backoff.InitialInterval == 0 {
+		opts.Backoff.InitialInterval = defaults.InitialInterval
+	}
+	if opts.Backoff.MaxInterval == 0 {
+		opts.Backoff.MaxInterval = defaults.MaxInterval
+	}
+	if opts.Backoff.Multiplier == 0 {
+		opts.Backoff.Multiplier = defaults.Multiplier
+	}
+	if opts.Backoff.IsRetryable == nil {
+		opts.Backoff.IsRetryable = func(error) bool { return true }
+	}
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return Add(&runnable{op: op, opts: opts, env: EnvFor(mgr, opts.Name)})(mgr)
+	}
+}
+
+// runnable implements [manager.Runnable] and [manager.LeaderElectionRunnable].
+type runnable struct {
+	op   fclient.ReaderIOEither[fclient.Unit]
+	opts RunnableOptions
+	env  func(context.Context) fclient.Env
+}
+
+func (r *runnable) NeedLeaderElection() bool {
+	return r.opts.NeedLeaderElection
+}
+
+func (r *runnable) Start(ctx context.Context) error {
+	run := fclient.Retry[fclient.Unit](r.opts.Backoff)(r.logFailures)
+	for {
+		_, err := ET.UnwrapError(run(r.env(ctx))())
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if r.opts.Interval <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(r.opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// logFailures runs op, logging its failures before they are retried.
+func (r *runnable) logFailures(env fclient.Env) fclient.IOEither[fclient.Unit] {
+	return func() fclient.Either[fclient.Unit] {
+		result := r.op(env)()
+		if err := ET.ToError(result); err != nil && env.Ctx.Err() == nil {
+			log.FromContext(env.Ctx).Error(err, "Runnable failed", "runnable", r.opts.Name)
+		}
+		return result
+	}
+}
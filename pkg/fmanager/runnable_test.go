@@ -0,0 +1,138 @@
+package fmanager_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	IOE "github.com/IBM/fp-go/ioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// capturingManager records the runnables added to it instead of running them.
+type capturingManager struct {
+	manager.Manager
+	added []manager.Runnable
+}
+
+func (m *capturingManager) Add(r manager.Runnable) error {
+	m.added = append(m.added, r)
+	return nil
+}
+
+var _ = Describe(
+	"Runnable", func() {
+
+		var runs atomic.Int32
+
+		// counting fails the first failures runs, then succeeds.
+		counting := func(failures int32) fclient.ReaderIOEither[fclient.Unit] {
+			return func(fclient.Env) fclient.IOEither[fclient.Unit] {
+				return func() fclient.Either[fclient.Unit] {
+					if runs.Add(1) <= failures {
+						return ET.Left[fclient.Unit](errors.New("external system unavailable"))
+					}
+					return ET.Right[error](fclient.UnitValue)
+				}
+			}
+		}
+
+		// start runs the manager with setups in the background, returning its result channel and a stop function.
+		start := func(setups ...fmanager.Setup) (<-chan fclient.Either[fclient.Unit], context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			done := make(chan fclient.Either[fclient.Unit], 1)
+			go func() {
+				done <- F.Pipe2(
+					newManager(),
+					IOE.Chain(fmanager.Register(setups...)),
+					IOE.Chain(fmanager.Run(ctx)),
+				)()
+			}()
+			return done, cancel
+		}
+
+		fastBackoff := fclient.RetryPolicy{InitialInterval: time.Millisecond, Multiplier: 1}
+
+		BeforeEach(
+			func() {
+				runs.Store(0)
+			},
+		)
+
+		It(
+			"should run once without an interval", func() {
+				done, stop := start(fmanager.Runnable(counting(0), fmanager.RunnableOptions{Name: "once"}))
+				Eventually(runs.Load).Should(Equal(int32(1)))
+				Consistently(runs.Load, 50*time.Millisecond).Should(Equal(int32(1)))
+				stop()
+				Eventually(done).Should(Receive(Equal(ET.Right[error](fclient.UnitValue))))
+			},
+		)
+
+		It(
+			"should run on an interval until the manager stops", func() {
+				done, stop := start(fmanager.Runnable(counting(0), fmanager.RunnableOptions{Interval: 5 * time.Millisecond}))
+				Eventually(runs.Load).Should(BeNumerically(">=", 3))
+				stop()
+				Eventually(done).Should(Receive(Equal(ET.Right[error](fclient.UnitValue))))
+			},
+		)
+
+		It(
+			"should restart with backoff after failures", func() {
+				done, stop := start(fmanager.Runnable(counting(2), fmanager.RunnableOptions{Backoff: fastBackoff}))
+				Eventually(runs.Load).Should(Equal(int32(3)))
+				Consistently(runs.Load, 50*time.Millisecond).Should(Equal(int32(3)))
+				stop()
+				Eventually(done).Should(Receive())
+			},
+		)
+
+		It(
+			"should stop the manager when the backoff gives up", func() {
+				backoff := fastBackoff
+				backoff.MaxElapsedTime = 20 * time.Millisecond
+				done, stop := start(fmanager.Runnable(counting(1000), fmanager.RunnableOptions{Backoff: backoff}))
+				defer stop()
+				var result fclient.Either[fclient.Unit]
+				Eventually(done).Should(Receive(&result))
+				Expect(ET.ToError(result)).To(MatchError("external system unavailable"))
+			},
+		)
+
+		It(
+			"should keep the backoff settings given without an initial interval", func() {
+				backoff := fclient.RetryPolicy{IsRetryable: func(error) bool { return false }}
+				done, stop := start(fmanager.Runnable(counting(1000), fmanager.RunnableOptions{Backoff: backoff}))
+				defer stop()
+				var result fclient.Either[fclient.Unit]
+				Eventually(done).Should(Receive(&result))
+				Expect(ET.ToError(result)).To(MatchError("external system unavailable"))
+				Expect(runs.Load()).To(Equal(int32(1)))
+			},
+		)
+
+		It(
+			"should declare whether it needs leader election", func() {
+				mgr, err := ET.UnwrapError(newManager()())
+				Expect(err).NotTo(HaveOccurred())
+				capturing := &capturingManager{Manager: mgr}
+				_, err = ET.UnwrapError(fmanager.Register(
+					fmanager.Runnable(counting(0), fmanager.RunnableOptions{NeedLeaderElection: true}),
+					fmanager.Runnable(counting(0), fmanager.RunnableOptions{}),
+				)(capturing)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(capturing.added).To(HaveLen(2))
+				Expect(capturing.added[0].(manager.LeaderElectionRunnable).NeedLeaderElection()).To(BeTrue())
+				Expect(capturing.added[1].(manager.LeaderElectionRunnable).NeedLeaderElection()).To(BeFalse())
+			},
+		)
+	},
+)
@@ -0,0 +1,196 @@
+// Package fpredicate provides typed, composable event predicates that compile to controller-runtime [predicate.Predicate].
+//
+// A Predicate is a set of pure functions over typed objects, so it can be tested without a manager:
+//
+//	p := fpredicate.And(fpredicate.GenerationChanged[*v1.Cat](), fpredicate.InNamespace[*v1.Cat]("default"))
+//	p.Update(oldCat, newCat)
+package fpredicate
+
+import (
+	"slices"
+
+	L "github.com/IBM/fp-go/optics/lens"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Predicate filters the events on objects of type T. Events it has no function for are accepted.
+type Predicate[T any] struct {
+	create  func(T) bool
+	update  func(oldObj, newObj T) bool
+	delete  func(T) bool
+	generic func(T) bool
+}
+
+// Create reports whether the creation of obj is accepted.
+func (p Predicate[T]) Create(obj T) bool {
+	return p.create == nil || p.create(obj)
+}
+
+// Update reports whether the update of oldObj into newObj is accepted.
+func (p Predicate[T]) Update(oldObj, newObj T) bool {
+	return p.update == nil || p.update(oldObj, newObj)
+}
+
+// Delete reports whether the deletion of obj is accepted.
+func (p Predicate[T]) Delete(obj T) bool {
+	return p.delete == nil || p.delete(obj)
+}
+
+// Generic reports whether a generic event on obj is accepted.
+func (p Predicate[T]) Generic(obj T) bool {
+	return p.generic == nil || p.generic(obj)
+}
+
+// Funcs builds a Predicate from one function per event type. Nil functions accept every event.
+func Funcs[T any](create func(T) bool, update func(oldObj, newObj T) bool, del func(T) bool, generic func(T) bool) Predicate[T] {
+	return Predicate[T]{create: create, update: update, delete: del, generic: generic}
+}
+
+// OnUpdate accepts the updates for which f holds, and every other event.
+func OnUpdate[T any](f func(oldObj, newObj T) bool) Predicate[T] {
+	return Predicate[T]{update: f}
+}
+
+// Matching accepts the events whose object satisfies f; for updates, the new object is tested.
+func Matching[T any](f func(T) bool) Predicate[T] {
+	return Predicate[T]{
+		create:  f,
+		update:  func(_, newObj T) bool { return f(newObj) },
+		delete:  f,
+		generic: f,
+	}
+}
+
+// And accepts the events accepted by every predicate.
+func And[T any](predicates ...Predicate[T]) Predicate[T] {
+	return Predicate[T]{
+		create: func(obj T) bool { return all(predicates, func(p Predicate[T]) bool { return p.Create(obj) }) },
+		update: func(oldObj, newObj T) bool {
+			return all(predicates, func(p Predicate[T]) bool { return p.Update(oldObj, newObj) })
+		},
+		delete:  func(obj T) bool { return all(predicates, func(p Predicate[T]) bool { return p.Delete(obj) }) },
+		generic: func(obj T) bool { return all(predicates, func(p Predicate[T]) bool { return p.Generic(obj) }) },
+	}
+}
+
+// Or accepts the events accepted by at least one predicate.
+func Or[T any](predicates ...Predicate[T]) Predicate[T] {
+	return Predicate[T]{
+		create: func(obj T) bool {
+			return slices.ContainsFunc(predicates, func(p Predicate[T]) bool { return p.Create(obj) })
+		},
+		update: func(oldObj, newObj T) bool {
+			return slices.ContainsFunc(predicates, func(p Predicate[T]) bool { return p.Update(oldObj, newObj) })
+		},
+		delete: func(obj T) bool {
+			return slices.ContainsFunc(predicates, func(p Predicate[T]) bool { return p.Delete(obj) })
+		},
+		generic: func(obj T) bool {
+			return slices.ContainsFunc(predicates, func(p Predicate[T]) bool { return p.Generic(obj) })
+		},
+	}
+}
+
+// Not accepts the events p rejects.
+func Not[T any](p Predicate[T]) Predicate[T] {
+	return Predicate[T]{
+		create:  func(obj T) bool { return !p.Create(obj) },
+		update:  func(oldObj, newObj T) bool { return !p.Update(oldObj, newObj) },
+		delete:  func(obj T) bool { return !p.Delete(obj) },
+		generic: func(obj T) bool { return !p.Generic(obj) },
+	}
+}
+
+// GenerationChanged accepts the updates changing metadata.generation, and every other event.
+func GenerationChanged[T client.Object]() Predicate[T] {
+	return OnUpdate(func(oldObj, newObj T) bool {
+		return oldObj.GetGeneration() != newObj.GetGeneration()
+	})
+}
+
+// LabelsChanged accepts the updates changing one of the given labels, or any label when none is given,
+// and every other event.
+func LabelsChanged[T client.Object](keys ...string) Predicate[T] {
+	return OnUpdate(func(oldObj, newObj T) bool {
+		return mapChanged(oldObj.GetLabels(), newObj.GetLabels(), keys)
+	})
+}
+
+// AnnotationChanged accepts the updates changing the annotation key, and every other event.
+func AnnotationChanged[T client.Object](key string) Predicate[T] {
+	return OnUpdate(func(oldObj, newObj T) bool {
+		return mapChanged(oldObj.GetAnnotations(), newObj.GetAnnotations(), []string{key})
+	})
+}
+
+// StatusFieldChanged accepts the updates changing the status field focused by lens, and every other event.
+// Field values are compared semantically.
+func StatusFieldChanged[T any, F any](lens L.Lens[T, F]) Predicate[T] {
+	return OnUpdate(func(oldObj, newObj T) bool {
+		return !equality.Semantic.DeepEqual(lens.Get(oldObj), lens.Get(newObj))
+	})
+}
+
+// InNamespace accepts the events on objects in one of the given namespaces.
+func InNamespace[T client.Object](namespaces ...string) Predicate[T] {
+	return Matching(func(obj T) bool {
+		return slices.Contains(namespaces, obj.GetNamespace())
+	})
+}
+
+// HasFinalizer accepts the events on objects carrying the finalizer name.
+func HasFinalizer[T client.Object](name string) Predicate[T] {
+	return Matching(func(obj T) bool {
+		return controllerutil.ContainsFinalizer(obj, name)
+	})
+}
+
+// ToPredicate compiles p to a controller-runtime predicate. Events on objects of another type are rejected.
+func ToPredicate[T client.Object](p Predicate[T]) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			obj, ok := e.Object.(T)
+			return ok && p.Create(obj)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObj, okOld := e.ObjectOld.(T)
+			newObj, okNew := e.ObjectNew.(T)
+			return okOld && okNew && p.Update(oldObj, newObj)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			obj, ok := e.Object.(T)
+			return ok && p.Delete(obj)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			obj, ok := e.Object.(T)
+			return ok && p.Generic(obj)
+		},
+	}
+}
+
+func all[T any](predicates []Predicate[T], f func(Predicate[T]) bool) bool {
+	return !slices.ContainsFunc(predicates, func(p Predicate[T]) bool { return !f(p) })
+}
+
+// mapChanged reports whether oldMap and newMap differ on keys, or at all when keys is empty.
+func mapChanged(oldMap, newMap map[string]string, keys []string) bool {
+	if len(keys) == 0 {
+		return !equality.Semantic.DeepEqual(nonNil(oldMap), nonNil(newMap))
+	}
+	return slices.ContainsFunc(keys, func(key string) bool {
+		oldValue, oldOK := oldMap[key]
+		newValue, newOK := newMap[key]
+		return oldOK != newOK || oldValue != newValue
+	})
+}
+
+func nonNil(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,119 @@
+package fpredicate_test
+
+import (
+	"testing"
+
+	L "github.com/IBM/fp-go/optics/lens"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fpredicate"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestFpredicate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fpredicate Suite")
+}
+
+// withMeta returns a Cat with the given metadata, changed by f.
+func withMeta(f func(*metav1.ObjectMeta)) *v1.Cat {
+	cat := &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default", Generation: 1}}
+	f(&cat.ObjectMeta)
+	return cat
+}
+
+var unchanged = withMeta(func(*metav1.ObjectMeta) {})
+
+var _ = Describe(
+	"Predicate", func() {
+
+		yes := fpredicate.Matching(func(*v1.Cat) bool { return true })
+		no := fpredicate.Matching(func(*v1.Cat) bool { return false })
+
+		It(
+			"should compose with And, Or and Not", func() {
+				Expect(fpredicate.And(yes, no).Create(unchanged)).To(BeFalse())
+				Expect(fpredicate.And(yes, yes).Create(unchanged)).To(BeTrue())
+				Expect(fpredicate.Or(no, yes).Delete(unchanged)).To(BeTrue())
+				Expect(fpredicate.Or(no, no).Generic(unchanged)).To(BeFalse())
+				Expect(fpredicate.Not(no).Update(unchanged, unchanged)).To(BeTrue())
+				Expect(fpredicate.And[*v1.Cat]().Create(unchanged)).To(BeTrue())
+				Expect(fpredicate.Or[*v1.Cat]().Create(unchanged)).To(BeFalse())
+			},
+		)
+
+		It(
+			"should detect generation changes on updates only", func() {
+				p := fpredicate.GenerationChanged[*v1.Cat]()
+				bumped := withMeta(func(m *metav1.ObjectMeta) { m.Generation = 2 })
+				Expect(p.Update(unchanged, bumped)).To(BeTrue())
+				Expect(p.Update(unchanged, unchanged)).To(BeFalse())
+				Expect(p.Create(unchanged)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should detect label changes, optionally limited to some keys", func() {
+				labeled := withMeta(func(m *metav1.ObjectMeta) { m.Labels = map[string]string{"color": "black"} })
+				Expect(fpredicate.LabelsChanged[*v1.Cat]().Update(unchanged, labeled)).To(BeTrue())
+				Expect(fpredicate.LabelsChanged[*v1.Cat]("color").Update(unchanged, labeled)).To(BeTrue())
+				Expect(fpredicate.LabelsChanged[*v1.Cat]("size").Update(unchanged, labeled)).To(BeFalse())
+				empty := withMeta(func(m *metav1.ObjectMeta) { m.Labels = map[string]string{} })
+				Expect(fpredicate.LabelsChanged[*v1.Cat]().Update(unchanged, empty)).To(BeFalse())
+			},
+		)
+
+		It(
+			"should detect annotation changes", func() {
+				annotated := withMeta(func(m *metav1.ObjectMeta) { m.Annotations = map[string]string{"mood": ""} })
+				Expect(fpredicate.AnnotationChanged[*v1.Cat]("mood").Update(unchanged, annotated)).To(BeTrue())
+				Expect(fpredicate.AnnotationChanged[*v1.Cat]("mood").Update(annotated, annotated)).To(BeFalse())
+			},
+		)
+
+		It(
+			"should detect changes of a status field focused by a lens", func() {
+				sleepy := L.MakeLensRef(
+					func(cat *v1.Cat) bool { return cat.Status != nil && cat.Status.Sleepy },
+					func(cat *v1.Cat, sleepy bool) *v1.Cat {
+						cat.Status = &v1.CatStatus{Sleepy: sleepy}
+						return cat
+					},
+				)
+				p := fpredicate.StatusFieldChanged(sleepy)
+				asleep := sleepy.Set(true)(unchanged)
+				Expect(p.Update(unchanged, asleep)).To(BeTrue())
+				Expect(p.Update(asleep, asleep.DeepCopy())).To(BeFalse())
+				Expect(unchanged.Status).To(BeNil(), "the lens must not mutate its source")
+			},
+		)
+
+		It(
+			"should filter by namespace and finalizer", func() {
+				finalized := withMeta(func(m *metav1.ObjectMeta) { m.Finalizers = []string{"test.appthrust.com/cleanup"} })
+				Expect(fpredicate.InNamespace[*v1.Cat]("default", "kube-system").Create(unchanged)).To(BeTrue())
+				Expect(fpredicate.InNamespace[*v1.Cat]("kube-system").Create(unchanged)).To(BeFalse())
+				Expect(fpredicate.HasFinalizer[*v1.Cat]("test.appthrust.com/cleanup").Update(unchanged, finalized)).To(BeTrue())
+				Expect(fpredicate.HasFinalizer[*v1.Cat]("test.appthrust.com/cleanup").Delete(unchanged)).To(BeFalse())
+			},
+		)
+
+		It(
+			"should compile to a controller-runtime predicate rejecting other types", func() {
+				p := fpredicate.ToPredicate(fpredicate.And(
+					fpredicate.GenerationChanged[*v1.Cat](),
+					fpredicate.InNamespace[*v1.Cat]("default"),
+				))
+				bumped := withMeta(func(m *metav1.ObjectMeta) { m.Generation = 2 })
+				Expect(p.Update(event.UpdateEvent{ObjectOld: unchanged, ObjectNew: bumped})).To(BeTrue())
+				Expect(p.Update(event.UpdateEvent{ObjectOld: unchanged, ObjectNew: unchanged})).To(BeFalse())
+				Expect(p.Create(event.CreateEvent{Object: unchanged})).To(BeTrue())
+				Expect(p.Delete(event.DeleteEvent{Object: &corev1.ConfigMap{}})).To(BeFalse())
+				Expect(p.Generic(event.GenericEvent{Object: unchanged})).To(BeTrue())
+			},
+		)
+	},
+)
@@ -0,0 +1,158 @@
+package freconcile
+
+import (
+	A "github.com/IBM/fp-go/array"
+	ET "github.com/IBM/fp-go/either"
+	F "github.com/IBM/fp-go/function"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// OwnerLabel is the label [ReconcileChildren] puts on every child; its value is the UID of the parent.
+const OwnerLabel = "fcr.appthrust.com/owner-uid"
+
+// ChildKind lists the children of one kind previously created for a parent, so that [ReconcileChildren] can prune them.
+type ChildKind func(parent client.Object) fclient.ReaderIOEither[[]client.Object]
+
+// Kind returns the ChildKind for objects of type O, listed through the list type OL.
+//
+// Children are discovered with [fclient.ListItems] in the parent's namespace by matching [OwnerLabel].
+func Kind[O any, OL any, OP fclient.ObjectPointer[O], OLP fclient.ObjectListPointer[OL]]() ChildKind {
+	return func(parent client.Object) fclient.ReaderIOEither[[]client.Object] {
+		params := fclient.ToListParams(
+			client.InNamespace(parent.GetNamespace()),
+			client.MatchingLabels{OwnerLabel: string(parent.GetUID())},
+		)
+		return F.Pipe1(
+			fclient.ListItems[O, OL, OP, OLP](params),
+			RIOE.Map[fclient.Env, error](A.Map(func(obj OP) client.Object { return obj })),
+		)
+	}
+}
+
+// ReconcileChildren applies the desired children of a parent and prunes the ones no longer desired.
+//
+// desired is a pure function returning the full set of children for the parent; it is called each time the
+// pipeline runs, so a retried pipeline applies fresh objects. Each child is labeled with [OwnerLabel], gets the
+// parent as controller owner reference, defaults to the parent's namespace, and is applied with server-side apply
+// as fieldOwner. Afterwards the children of the given kinds that are controlled by the parent but not part of the
+// desired set are deleted. Children are applied, listed and deleted one at a time, in order, stopping at the first
+// failure.
+func ReconcileChildren[T any, OP fclient.ObjectPointer[T]](fieldOwner string, desired func(OP) []client.Object, kinds ...ChildKind) func(OP) fclient.ReaderIOEither[fclient.Unit] {
+	return func(parent OP) fclient.ReaderIOEither[fclient.Unit] {
+		return RIOE.Defer(func() fclient.ReaderIOEither[fclient.Unit] {
+			children := desired(parent)
+			return F.Pipe3(
+				children,
+				traverseInOrder(applyChild(fieldOwner, parent)),
+				RIOE.Chain(func([]childKey) fclient.ReaderIOEither[[][]client.Object] {
+					return traverseInOrder(func(kind ChildKind) fclient.ReaderIOEither[[]client.Object] {
+						return kind(parent)
+					})(kinds)
+				}),
+				RIOE.Chain(func(existing [][]client.Object) fclient.ReaderIOEither[fclient.Unit] {
+					return pruneChildren(parent, desiredKeys(children), A.Flatten(existing))
+				}),
+			)
+		})
+	}
+}
+
+// traverseInOrder is RIOE.TraverseArray running f on one item at a time, in order, and stopping at the first
+// Left, where RIOE.TraverseArray runs every item concurrently.
+func traverseInOrder[A, B any](f func(A) fclient.ReaderIOEither[B]) func([]A) fclient.ReaderIOEither[[]B] {
+	return func(items []A) fclient.ReaderIOEither[[]B] {
+		return func(env fclient.Env) fclient.IOEither[[]B] {
+			return func() fclient.Either[[]B] {
+				results := make([]B, 0, len(items))
+				for _, item := range items {
+					result, err := ET.UnwrapError(f(item)(env)())
+					if err != nil {
+						return ET.Left[[]B](err)
+					}
+					results = append(results, result)
+				}
+				return ET.Right[error](results)
+			}
+		}
+	}
+}
+
+// childKey identifies a child across kinds.
+type childKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// applyChild prepares a desired child for its parent and applies it, yielding its key.
+func applyChild(fieldOwner string, parent client.Object) func(client.Object) fclient.ReaderIOEither[childKey] {
+	return func(child client.Object) fclient.ReaderIOEither[childKey] {
+		return func(env fclient.Env) fclient.IOEither[childKey] {
+			return func() fclient.Either[childKey] {
+				scheme := env.Client.Scheme()
+				gvk, err := apiutil.GVKForObject(child, scheme)
+				if err != nil {
+					return RIOE.Left[fclient.Env, childKey](err)(env)()
+				}
+				child.GetObjectKind().SetGroupVersionKind(gvk)
+				if child.GetNamespace() == "" {
+					child.SetNamespace(parent.GetNamespace())
+				}
+				labels := child.GetLabels()
+				if labels == nil {
+					labels = map[string]string{}
+				}
+				labels[OwnerLabel] = string(parent.GetUID())
+				child.SetLabels(labels)
+				if err := controllerutil.SetControllerReference(parent, child, scheme); err != nil {
+					return RIOE.Left[fclient.Env, childKey](err)(env)()
+				}
+				return F.Pipe1(
+					fclient.Patch(fclient.ToPatchParams(child, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership)),
+					RIOE.MapTo[fclient.Env, error, fclient.Unit](keyOf(gvk, child)),
+				)(env)()
+			}
+		}
+	}
+}
+
+// pruneChildren deletes the existing children controlled by parent whose keys are not desired.
+func pruneChildren(parent client.Object, desired map[childKey]bool, existing []client.Object) fclient.ReaderIOEither[fclient.Unit] {
+	return func(env fclient.Env) fclient.IOEither[fclient.Unit] {
+		stale := A.Filter(func(child client.Object) bool {
+			if !metav1.IsControlledBy(child, parent) {
+				return false
+			}
+			gvk, err := apiutil.GVKForObject(child, env.Client.Scheme())
+			return err == nil && !desired[keyOf(gvk, child)]
+		})(existing)
+		return F.Pipe2(
+			stale,
+			traverseInOrder(func(child client.Object) fclient.ReaderIOEither[fclient.Unit] {
+				return F.Pipe1(
+					fclient.Delete(fclient.ToDeleteParams(child, client.PropagationPolicy(metav1.DeletePropagationBackground))),
+					RIOE.OrElse(ignoreNotFound),
+				)
+			}),
+			RIOE.MapTo[fclient.Env, error, []fclient.Unit](fclient.UnitValue),
+		)(env)
+	}
+}
+
+func desiredKeys(children []client.Object) map[childKey]bool {
+	keys := make(map[childKey]bool, len(children))
+	for _, child := range children {
+		keys[keyOf(child.GetObjectKind().GroupVersionKind(), child)] = true
+	}
+	return keys
+}
+
+func keyOf(gvk schema.GroupVersionKind, obj client.Object) childKey {
+	return childKey{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}
+}
@@ -0,0 +1,176 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+
+	ET "github.com/IBM/fp-go/either"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// emulateApply turns server-side apply patches, which the fake client rejects, into creates and updates.
+// Like the API server, it rejects applied objects carrying server state such as managed fields; the fake client
+// records none, so the resource version stands for it.
+func emulateApply(applied *[]string) interceptor.Funcs {
+	return interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() != types.ApplyPatchType {
+				return c.Patch(ctx, obj, patch, opts...)
+			}
+			*applied = append(*applied, obj.GetName())
+			if obj.GetResourceVersion() != "" {
+				return apierrors.NewBadRequest("metadata.managedFields must be nil")
+			}
+			existing := obj.DeepCopyObject().(client.Object)
+			err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+			if apierrors.IsNotFound(err) {
+				return c.Create(ctx, obj)
+			}
+			if err != nil {
+				return err
+			}
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			return c.Update(ctx, obj)
+		},
+	}
+}
+
+var _ = Describe(
+	"ReconcileChildren", func() {
+
+		var cl client.Client
+		var applied []string
+
+		cat := &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default", UID: "tom-uid"}}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+
+		ownedBy := func(parent *v1.Cat, name string) *corev1.ConfigMap {
+			return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{freconcile.OwnerLabel: string(parent.UID)},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v1.GroupVersion.String(),
+					Kind:       "Cat",
+					Name:       parent.Name,
+					UID:        parent.UID,
+					Controller: func() *bool { b := true; return &b }(),
+				}},
+			}}
+		}
+
+		children := func(parent *v1.Cat, names ...string) fclient.ReaderIOEither[fclient.Unit] {
+			return freconcile.ReconcileChildren[v1.Cat](
+				"cat-controller",
+				func(*v1.Cat) []client.Object {
+					children := make([]client.Object, 0, len(names))
+					for _, name := range names {
+						children = append(children, &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{Name: name},
+							Data:       map[string]string{"owner": parent.Name},
+						})
+					}
+					return children
+				},
+				freconcile.Kind[corev1.ConfigMap, corev1.ConfigMapList](),
+			)(parent)
+		}
+
+		reconcileChildren := func(names ...string) error {
+			r := freconcile.Func(cl, freconcile.For[v1.Cat](func(cat *v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+				return RIOE.MapTo[fclient.Env, error, fclient.Unit](reconcile.Result{})(children(cat, names...))
+			}))
+			_, err := r.Reconcile(context.TODO(), req)
+			return err
+		}
+
+		BeforeEach(
+			func() {
+				applied = nil
+			},
+		)
+
+		It(
+			"should apply the desired children owned by the parent", func() {
+				cl = newInterceptedFakeClient(emulateApply(&applied), cat.DeepCopy())
+				Expect(reconcileChildren("food", "toys")).To(Succeed())
+				Expect(applied).To(Equal([]string{"food", "toys"}))
+
+				var cm corev1.ConfigMap
+				Expect(cl.Get(context.TODO(), client.ObjectKey{Name: "food", Namespace: "default"}, &cm)).To(Succeed())
+				Expect(cm.Data).To(HaveKeyWithValue("owner", "tom"))
+				Expect(cm.Labels).To(HaveKeyWithValue(freconcile.OwnerLabel, "tom-uid"))
+				Expect(metav1.IsControlledBy(&cm, cat)).To(BeTrue())
+			},
+		)
+
+		It(
+			"should prune children that are no longer desired", func() {
+				cl = newInterceptedFakeClient(emulateApply(&applied), cat.DeepCopy(), ownedBy(cat, "food"), ownedBy(cat, "toys"))
+				Expect(reconcileChildren("food")).To(Succeed())
+
+				var list corev1.ConfigMapList
+				Expect(cl.List(context.TODO(), &list)).To(Succeed())
+				Expect(list.Items).To(HaveLen(1))
+				Expect(list.Items[0].Name).To(Equal("food"))
+			},
+		)
+
+		It(
+			"should leave labeled children controlled by someone else alone", func() {
+				other := &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "jerry", UID: "jerry-uid"}}
+				foreign := ownedBy(other, "mouse")
+				foreign.Labels[freconcile.OwnerLabel] = string(cat.UID)
+				cl = newInterceptedFakeClient(emulateApply(&applied), cat.DeepCopy(), foreign)
+				Expect(reconcileChildren()).To(Succeed())
+
+				var cm corev1.ConfigMap
+				Expect(cl.Get(context.TODO(), client.ObjectKey{Name: "mouse", Namespace: "default"}, &cm)).To(Succeed())
+			},
+		)
+
+		It(
+			"should apply fresh children each time the pipeline runs", func() {
+				cl = newInterceptedFakeClient(emulateApply(&applied), cat.DeepCopy())
+				env := fclient.Env{Ctx: context.TODO(), Client: cl}
+				pipeline := children(cat.DeepCopy(), "food")
+				Expect(ET.IsRight(pipeline(env)())).To(BeTrue())
+				Expect(ET.IsRight(pipeline(env)())).To(BeTrue())
+				Expect(applied).To(Equal([]string{"food", "food"}))
+			},
+		)
+
+		It(
+			"should stop applying at the first failing child", func() {
+				funcs := emulateApply(&applied)
+				apply := funcs.Patch
+				funcs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if obj.GetName() == "food" {
+						applied = append(applied, obj.GetName())
+						return apierrors.NewForbidden(corev1.Resource("configmaps"), obj.GetName(), errors.New("no food"))
+					}
+					return apply(ctx, c, obj, patch, opts...)
+				}
+				cl = newInterceptedFakeClient(funcs, cat.DeepCopy(), ownedBy(cat, "stale"))
+				Expect(reconcileChildren("food", "toys")).To(MatchError(ContainSubstring("no food")))
+				Expect(applied).To(Equal([]string{"food"}))
+
+				var list corev1.ConfigMapList
+				Expect(cl.List(context.TODO(), &list)).To(Succeed())
+				Expect(list.Items).To(ConsistOf(HaveField("Name", "stale")))
+			},
+		)
+	},
+)
@@ -0,0 +1,120 @@
+package freconcile
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Condition types maintained by [TrackReadiness].
+const (
+	ConditionReady       = "Ready"
+	ConditionReconciling = "Reconciling"
+	ConditionDegraded    = "Degraded"
+)
+
+// Condition reasons set by [TrackReadiness].
+const (
+	ReasonReconciled  = "Reconciled"
+	ReasonProgressing = "Progressing"
+	ReasonFailed      = "Failed"
+)
+
+// ConditionedObject is an object exposing status conditions and the generation observed by its controller.
+type ConditionedObject interface {
+	client.Object
+	GetConditions() []metav1.Condition
+	SetConditions([]metav1.Condition)
+	SetObservedGeneration(int64)
+}
+
+// TrackReadiness wraps an ObjectReconciler so that the object's status reports how the reconcile went.
+//
+// After f runs, the observed generation is set to the object's generation and the Ready, Reconciling and
+// Degraded conditions are derived from the result:
+//   - a Left marks the object Degraded with the error message, truncated to the length a condition allows,
+//   - a Right asking for a requeue marks the object Reconciling,
+//   - any other Right marks the object Ready.
+//
+// The status is then persisted with [PatchStatus], so nothing is written when it did not change.
+func TrackReadiness[T any, OP interface {
+	fclient.ObjectPointer[T]
+	ConditionedObject
+}](f ObjectReconciler[OP]) ObjectReconciler[OP] {
+	return PatchStatus[T, OP](func(obj OP) fclient.ReaderIOEither[reconcile.Result] {
+		return func(env fclient.Env) fclient.IOEither[reconcile.Result] {
+			return func() fclient.Either[reconcile.Result] {
+				result := f(obj)(env)()
+				setReadiness(obj, result)
+				return result
+			}
+		}
+	})
+}
+
+func setReadiness(obj ConditionedObject, result fclient.Either[reconcile.Result]) {
+	generation := obj.GetGeneration()
+	obj.SetObservedGeneration(generation)
+	conditions := obj.GetConditions()
+	for _, c := range readinessConditions(result) {
+		c.ObservedGeneration = generation
+		apimeta.SetStatusCondition(&conditions, c)
+	}
+	obj.SetConditions(conditions)
+}
+
+func readinessConditions(result fclient.Either[reconcile.Result]) []metav1.Condition {
+	return ET.Fold(
+		func(err error) []metav1.Condition {
+			return []metav1.Condition{
+				{Type: ConditionReady, Status: metav1.ConditionFalse, Reason: ReasonFailed, Message: conditionMessage(err.Error())},
+				{Type: ConditionReconciling, Status: metav1.ConditionFalse, Reason: ReasonFailed},
+				{Type: ConditionDegraded, Status: metav1.ConditionTrue, Reason: ReasonFailed, Message: conditionMessage(err.Error())},
+			}
+		},
+		func(r reconcile.Result) []metav1.Condition {
+			if requeued(r) {
+				return []metav1.Condition{
+					{Type: ConditionReady, Status: metav1.ConditionFalse, Reason: ReasonProgressing, Message: requeueMessage(r)},
+					{Type: ConditionReconciling, Status: metav1.ConditionTrue, Reason: ReasonProgressing, Message: requeueMessage(r)},
+					{Type: ConditionDegraded, Status: metav1.ConditionFalse, Reason: ReasonProgressing},
+				}
+			}
+			return []metav1.Condition{
+				{Type: ConditionReady, Status: metav1.ConditionTrue, Reason: ReasonReconciled},
+				{Type: ConditionReconciling, Status: metav1.ConditionFalse, Reason: ReasonReconciled},
+				{Type: ConditionDegraded, Status: metav1.ConditionFalse, Reason: ReasonReconciled},
+			}
+		},
+	)(result)
+}
+
+// maxMessageLength is the maximum length of a condition message, enforced by the API server on [metav1.Condition].
+const maxMessageLength = 32768
+
+// conditionMessage truncates msg to the maximum length of a condition message, so that a long error does not make
+// the status update itself fail.
+func conditionMessage(msg string) string {
+	const ellipsis = "..."
+	if utf8.RuneCountInString(msg) <= maxMessageLength {
+		return msg
+	}
+	return string([]rune(msg)[:maxMessageLength-len(ellipsis)]) + ellipsis
+}
+
+func requeued(r reconcile.Result) bool {
+	return r.RequeueAfter > 0 || r.Requeue //nolint:staticcheck // Requeue is still honoured by controller-runtime.
+}
+
+func requeueMessage(r reconcile.Result) string {
+	if r.RequeueAfter > 0 {
+		return fmt.Sprintf("reconcile requeued after %s", r.RequeueAfter)
+	}
+	return "reconcile requeued"
+}
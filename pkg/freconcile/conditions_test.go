@@ -0,0 +1,97 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe(
+	"TrackReadiness", func() {
+
+		var cl client.Client
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+
+		BeforeEach(
+			func() {
+				cl = newFakeClient(&v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default", Generation: 3}})
+			},
+		)
+
+		reconcileWith := func(result reconcile.Result, err error) *v1.Cat {
+			r := freconcile.Func(cl, freconcile.For[v1.Cat](freconcile.TrackReadiness(func(*v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+				if err != nil {
+					return RIOE.Left[fclient.Env, reconcile.Result](err)
+				}
+				return RIOE.Right[fclient.Env, error](result)
+			})))
+			_, _ = r.Reconcile(context.TODO(), req)
+			var cat v1.Cat
+			Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+			return &cat
+		}
+
+		It(
+			"should mark a successful reconcile Ready", func() {
+				cat := reconcileWith(reconcile.Result{}, nil)
+				Expect(cat.Status.ObservedGeneration).To(Equal(int64(3)))
+				Expect(apimeta.IsStatusConditionTrue(cat.GetConditions(), freconcile.ConditionReady)).To(BeTrue())
+				Expect(apimeta.IsStatusConditionFalse(cat.GetConditions(), freconcile.ConditionReconciling)).To(BeTrue())
+				Expect(apimeta.IsStatusConditionFalse(cat.GetConditions(), freconcile.ConditionDegraded)).To(BeTrue())
+				Expect(apimeta.FindStatusCondition(cat.GetConditions(), freconcile.ConditionReady).ObservedGeneration).To(Equal(int64(3)))
+			},
+		)
+
+		It(
+			"should mark a requeued reconcile Reconciling", func() {
+				cat := reconcileWith(reconcile.Result{RequeueAfter: 30 * time.Second}, nil)
+				Expect(apimeta.IsStatusConditionFalse(cat.GetConditions(), freconcile.ConditionReady)).To(BeTrue())
+				Expect(apimeta.IsStatusConditionTrue(cat.GetConditions(), freconcile.ConditionReconciling)).To(BeTrue())
+				Expect(apimeta.FindStatusCondition(cat.GetConditions(), freconcile.ConditionReconciling).Message).To(ContainSubstring("30s"))
+			},
+		)
+
+		It(
+			"should mark a failed reconcile Degraded with the error message", func() {
+				cat := reconcileWith(reconcile.Result{}, errors.New("the litter box is full"))
+				Expect(cat.Status.ObservedGeneration).To(Equal(int64(3)))
+				Expect(apimeta.IsStatusConditionFalse(cat.GetConditions(), freconcile.ConditionReady)).To(BeTrue())
+				degraded := apimeta.FindStatusCondition(cat.GetConditions(), freconcile.ConditionDegraded)
+				Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+				Expect(degraded.Message).To(Equal("the litter box is full"))
+			},
+		)
+
+		It(
+			"should truncate error messages to the maximum length of a condition message", func() {
+				cat := reconcileWith(reconcile.Result{}, errors.New(strings.Repeat("é", 40000)))
+				degraded := apimeta.FindStatusCondition(cat.GetConditions(), freconcile.ConditionDegraded)
+				Expect(utf8.RuneCountInString(degraded.Message)).To(Equal(32768))
+				Expect(degraded.Message).To(HaveSuffix("é..."))
+			},
+		)
+
+		It(
+			"should not write again when the readiness is unchanged", func() {
+				first := reconcileWith(reconcile.Result{}, nil)
+				second := reconcileWith(reconcile.Result{}, nil)
+				Expect(second.ResourceVersion).To(Equal(first.ResourceVersion))
+			},
+		)
+	},
+)
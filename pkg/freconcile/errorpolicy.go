@@ -0,0 +1,119 @@
+package freconcile
+
+import (
+	"errors"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ErrorAction decides the Outcome of a reconcile that failed with an error matched by an [ErrorRule].
+type ErrorAction struct {
+	outcome func(reconcile.Request, error) Outcome
+	forget  func(reconcile.Request)
+}
+
+// RequeueNow requeues the request without logging the error.
+//
+// The requeue goes through the controller's per-request rate limiter, so it is immediate at first and backs off
+// while the error persists, instead of spinning on the workqueue.
+func RequeueNow() ErrorAction {
+	return ErrorAction{outcome: func(reconcile.Request, error) Outcome { return Requeue() }}
+}
+
+// RequeueWithBackoff requeues the request after a per-request exponential backoff between base and maxDelay.
+//
+// The backoff of a request is reset once it reconciles successfully.
+func RequeueWithBackoff(base, maxDelay time.Duration) ErrorAction {
+	limiter := workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](base, maxDelay)
+	return ErrorAction{
+		outcome: func(req reconcile.Request, _ error) Outcome { return RequeueAfter(limiter.When(req)) },
+		forget:  limiter.Forget,
+	}
+}
+
+// AsTerminal fails the reconcile with a terminal error, so it is not retried until the object changes.
+func AsTerminal() ErrorAction {
+	return ErrorAction{outcome: func(_ reconcile.Request, err error) Outcome { return Terminal(err) }}
+}
+
+// Ignore treats the reconcile as Done, dropping the error.
+func Ignore() ErrorAction {
+	return ErrorAction{outcome: func(reconcile.Request, error) Outcome { return Done() }}
+}
+
+// ErrorRule maps the errors it matches to an [ErrorAction].
+type ErrorRule struct {
+	matches func(error) bool
+	action  ErrorAction
+}
+
+// When is the ErrorRule applying action to the errors matched by match, such as [apierrors.IsConflict].
+func When(match func(error) bool, action ErrorAction) ErrorRule {
+	return ErrorRule{matches: match, action: action}
+}
+
+// ErrorIs matches errors whose chain contains target, as reported by errors.Is.
+func ErrorIs(target error) func(error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// ErrorAs matches errors whose chain contains an error of type E, as reported by errors.As.
+func ErrorAs[E error]() func(error) bool {
+	return func(err error) bool {
+		var target E
+		return errors.As(err, &target)
+	}
+}
+
+// IsValidation matches errors reporting an invalid or malformed request.
+func IsValidation(err error) bool {
+	return apierrors.IsInvalid(err) || apierrors.IsBadRequest(err)
+}
+
+// WithErrorPolicy wraps a Reconciler so that its failures are handled by the first matching rule.
+//
+// Terminal errors and errors no rule matches are returned unchanged, so the latter are still retried with the
+// controller's rate limiter. Successful reconciles reset the backoff of [RequeueWithBackoff] rules.
+func WithErrorPolicy(rules ...ErrorRule) func(Reconciler) Reconciler {
+	return func(f Reconciler) Reconciler {
+		return func(req reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+			return func(env fclient.Env) fclient.IOEither[reconcile.Result] {
+				return func() fclient.Either[reconcile.Result] {
+					result := f(req)(env)()
+					_, err := ET.UnwrapError(result)
+					if err == nil {
+						forgetAll(rules, req)
+						return result
+					}
+					if isTerminal(err) {
+						return result
+					}
+					for _, rule := range rules {
+						if rule.matches(err) {
+							outcome := rule.action.outcome(req, err)
+							log.FromContext(env.Ctx).V(1).Info("Handled reconcile error by policy", "error", err.Error(), "outcome", outcome.String())
+							return ET.TryCatchError(outcome.Result())
+						}
+					}
+					return result
+				}
+			}
+		}
+	}
+}
+
+func forgetAll(rules []ErrorRule, req reconcile.Request) {
+	for _, rule := range rules {
+		if rule.action.forget != nil {
+			rule.action.forget(req)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// quotaError is a domain error used to exercise ErrorAs.
+type quotaError struct{}
+
+func (quotaError) Error() string { return "quota exceeded" }
+
+var _ = Describe(
+	"WithErrorPolicy", func() {
+
+		var failWith error
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+		env := fclient.Env{Ctx: context.TODO()}
+		gr := v1.GroupVersion.WithResource("cats").GroupResource()
+
+		failing := func(reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+			if failWith != nil {
+				return RIOE.Left[fclient.Env, reconcile.Result](failWith)
+			}
+			return RIOE.Right[fclient.Env, error](reconcile.Result{})
+		}
+
+		policy := freconcile.WithErrorPolicy(
+			freconcile.When(apierrors.IsNotFound, freconcile.RequeueWithBackoff(time.Second, time.Minute)),
+			freconcile.When(apierrors.IsConflict, freconcile.RequeueNow()),
+			freconcile.When(freconcile.IsValidation, freconcile.AsTerminal()),
+			freconcile.When(apierrors.IsForbidden, freconcile.Ignore()),
+			freconcile.When(freconcile.ErrorAs[quotaError](), freconcile.RequeueWithBackoff(time.Minute, time.Hour)),
+		)
+
+		run := func(r freconcile.Reconciler) (reconcile.Result, error) {
+			return ET.UnwrapError(r(req)(env)())
+		}
+
+		It(
+			"should requeue conflicts through the controller's rate limiter, backing off while they persist", func() {
+				failWith = apierrors.NewConflict(gr, "tom", errors.New("stale"))
+				r := policy(failing)
+				// The controller adds plain requeues to the workqueue rate limited, and forgets the request's backoff
+				// on any RequeueAfter.
+				limiter := workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]()
+				var delays []time.Duration
+				for range 5 {
+					result, err := run(r)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeTrue()) //nolint:staticcheck // Requeue is what RequeueNow produces.
+					Expect(result.RequeueAfter).To(BeZero())
+					delays = append(delays, limiter.When(req))
+				}
+				Expect(delays).To(Equal([]time.Duration{
+					5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 80 * time.Millisecond,
+				}))
+			},
+		)
+
+		It(
+			"should back off per request and reset after a success", func() {
+				r := policy(failing)
+				failWith = fmt.Errorf("get owner: %w", apierrors.NewNotFound(gr, "owner"))
+				first, err := run(r)
+				Expect(err).NotTo(HaveOccurred())
+				second, _ := run(r)
+				Expect(first.RequeueAfter).To(Equal(time.Second))
+				Expect(second.RequeueAfter).To(Equal(2 * time.Second))
+
+				failWith = nil
+				_, err = run(r)
+				Expect(err).NotTo(HaveOccurred())
+
+				failWith = apierrors.NewNotFound(gr, "owner")
+				again, _ := run(r)
+				Expect(again.RequeueAfter).To(Equal(time.Second))
+			},
+		)
+
+		It(
+			"should turn validation errors terminal", func() {
+				failWith = apierrors.NewBadRequest("spec.lives must be at most 9")
+				_, err := run(policy(failing))
+				Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+				Expect(err).To(MatchError(ContainSubstring("spec.lives")))
+			},
+		)
+
+		It(
+			"should ignore errors mapped to Ignore", func() {
+				failWith = apierrors.NewForbidden(gr, "tom", errors.New("no"))
+				result, err := run(policy(failing))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{}))
+			},
+		)
+
+		It(
+			"should match custom domain errors", func() {
+				failWith = fmt.Errorf("provision: %w", quotaError{})
+				result, err := run(policy(failing))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(time.Minute))
+			},
+		)
+
+		It(
+			"should return unmatched errors unchanged", func() {
+				failWith = errors.New("boom")
+				_, err := run(policy(failing))
+				Expect(err).To(BeIdenticalTo(failWith))
+			},
+		)
+
+		It(
+			"should leave terminal errors alone even when a rule matches", func() {
+				failWith = reconcile.TerminalError(apierrors.NewConflict(gr, "tom", errors.New("stale")))
+				_, err := run(policy(failing))
+				Expect(err).To(BeIdenticalTo(failWith))
+			},
+		)
+
+		It(
+			"should match sentinel errors with ErrorIs", func() {
+				errLocked := errors.New("locked")
+				failWith = fmt.Errorf("acquire: %w", errLocked)
+				result, err := run(freconcile.WithErrorPolicy(freconcile.When(freconcile.ErrorIs(errLocked), freconcile.RequeueNow()))(failing))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeTrue()) //nolint:staticcheck // Requeue is what RequeueNow produces.
+			},
+		)
+	},
+)
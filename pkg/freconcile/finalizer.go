@@ -0,0 +1,92 @@
+package freconcile
+
+import (
+	"slices"
+
+	F "github.com/IBM/fp-go/function"
+	O "github.com/IBM/fp-go/option"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// WithFinalizer creates a Reconciler that manages the lifecycle of the named finalizer.
+//
+// Live objects get the finalizer added before reconcileFn runs. Objects being deleted that carry the
+// finalizer are handed to finalize, and the finalizer is removed only when finalize returns a Right asking
+// for no requeue: a finalize waiting on external cleanup returns a requeue and keeps the finalizer until it
+// is called again. Objects being deleted without the finalizer are skipped.
+//
+// Removing the finalizer re-reads the object and retries on conflicts; an object that is already gone
+// counts as finalized. The options are passed to [For]; an [OnDeleting] among them is overridden,
+// since deletion is handled by finalize.
+func WithFinalizer[T any, OP fclient.ObjectPointer[T]](name string, reconcileFn, finalize ObjectReconciler[OP], opts ...ForOption[OP]) Reconciler {
+	return For[T, OP](
+		func(obj OP) fclient.ReaderIOEither[reconcile.Result] {
+			return F.Pipe1(
+				addFinalizer(name, obj),
+				RIOE.Chain(func(fclient.Unit) fclient.ReaderIOEither[reconcile.Result] { return reconcileFn(obj) }),
+			)
+		},
+		append(slices.Clone(opts), OnDeleting(func(obj OP) fclient.ReaderIOEither[reconcile.Result] {
+			if !controllerutil.ContainsFinalizer(obj, name) {
+				return done(reconcile.Request{})
+			}
+			return F.Pipe1(
+				finalize(obj),
+				RIOE.ChainFirst(func(result reconcile.Result) fclient.ReaderIOEither[fclient.Unit] {
+					if !result.IsZero() {
+						return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+					}
+					return removeFinalizer[T, OP](name, client.ObjectKeyFromObject(obj))
+				}),
+			)
+		}))...,
+	)
+}
+
+// addFinalizer adds the finalizer to obj and patches it when missing.
+func addFinalizer(name string, obj client.Object) fclient.ReaderIOEither[fclient.Unit] {
+	return RIOE.Defer(func() fclient.ReaderIOEither[fclient.Unit] {
+		if controllerutil.ContainsFinalizer(obj, name) {
+			return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+		}
+		before, _ := obj.DeepCopyObject().(client.Object)
+		controllerutil.AddFinalizer(obj, name)
+		return fclient.Patch(fclient.ToPatchParams(obj, client.MergeFromWithOptions(before, client.MergeFromWithOptimisticLock{})))
+	})
+}
+
+// removeFinalizer re-reads the object and removes the finalizer, retrying on conflicts.
+func removeFinalizer[T any, OP fclient.ObjectPointer[T]](name string, key client.ObjectKey) fclient.ReaderIOEither[fclient.Unit] {
+	policy := fclient.DefaultRetryPolicy()
+	policy.IsRetryable = apierrors.IsConflict
+	return F.Pipe2(
+		fclient.GetOption[T, OP](fclient.ToGetParams(key)),
+		RIOE.Chain(O.Fold(
+			F.Constant(RIOE.Right[fclient.Env, error](fclient.UnitValue)),
+			func(obj OP) fclient.ReaderIOEither[fclient.Unit] {
+				if !controllerutil.ContainsFinalizer(obj, name) {
+					return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+				}
+				before, _ := obj.DeepCopyObject().(client.Object)
+				controllerutil.RemoveFinalizer(obj, name)
+				return F.Pipe1(
+					fclient.Patch(fclient.ToPatchParams(obj, client.MergeFromWithOptions(before, client.MergeFromWithOptimisticLock{}))),
+					RIOE.OrElse(ignoreNotFound),
+				)
+			},
+		)),
+		fclient.Retry[fclient.Unit](policy),
+	)
+}
+
+func ignoreNotFound(err error) fclient.ReaderIOEither[fclient.Unit] {
+	if client.IgnoreNotFound(err) == nil {
+		return RIOE.Right[fclient.Env, error](fclient.UnitValue)
+	}
+	return RIOE.Left[fclient.Env, fclient.Unit](err)
+}
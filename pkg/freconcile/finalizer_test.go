@@ -0,0 +1,192 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe(
+	"WithFinalizer", func() {
+
+		const finalizer = "test.appthrust.com/cleanup"
+
+		var reconciled, finalized []string
+		var finalizeErr error
+		var finalizeResult reconcile.Result
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+
+		BeforeEach(
+			func() {
+				reconciled, finalized, finalizeErr, finalizeResult = nil, nil, nil, reconcile.Result{}
+			},
+		)
+
+		newReconciler := func(cl client.Client) reconcile.Reconciler {
+			return freconcile.Func(cl, freconcile.WithFinalizer[v1.Cat](
+				finalizer,
+				func(cat *v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+					reconciled = append(reconciled, cat.Name)
+					return RIOE.Right[fclient.Env, error](reconcile.Result{})
+				},
+				func(cat *v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+					finalized = append(finalized, cat.Name)
+					if finalizeErr != nil {
+						return RIOE.Left[fclient.Env, reconcile.Result](finalizeErr)
+					}
+					return RIOE.Right[fclient.Env, error](finalizeResult)
+				},
+			))
+		}
+
+		deletingCat := func(finalizers ...string) *v1.Cat {
+			now := metav1.Now()
+			return &v1.Cat{ObjectMeta: metav1.ObjectMeta{
+				Name:              "tom",
+				Namespace:         "default",
+				DeletionTimestamp: &now,
+				Finalizers:        finalizers,
+			}}
+		}
+
+		It(
+			"should add the finalizer on first sight and reconcile", func() {
+				cl := newFakeClient(&v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default"}})
+				_, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reconciled).To(Equal([]string{"tom"}))
+
+				var cat v1.Cat
+				Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+				Expect(cat.Finalizers).To(ContainElement(finalizer))
+			},
+		)
+
+		It(
+			"should finalize and remove the finalizer of a deleted object", func() {
+				cl := newFakeClient(deletingCat(finalizer, "other"))
+				_, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finalized).To(Equal([]string{"tom"}))
+				Expect(reconciled).To(BeEmpty())
+
+				var cat v1.Cat
+				Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+				Expect(cat.Finalizers).To(Equal([]string{"other"}))
+			},
+		)
+
+		It(
+			"should keep the finalizer when finalize fails", func() {
+				finalizeErr = errors.New("external cleanup failed")
+				cl := newFakeClient(deletingCat(finalizer))
+				_, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).To(MatchError(finalizeErr))
+
+				var cat v1.Cat
+				Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+				Expect(cat.Finalizers).To(Equal([]string{finalizer}))
+			},
+		)
+
+		It(
+			"should keep the finalizer while finalize asks for a requeue", func() {
+				finalizeResult = reconcile.Result{RequeueAfter: time.Minute}
+				cl := newFakeClient(deletingCat(finalizer))
+				result, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+				var cat v1.Cat
+				Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+				Expect(cat.Finalizers).To(Equal([]string{finalizer}))
+			},
+		)
+
+		It(
+			"should skip deleted objects without the finalizer", func() {
+				cl := newFakeClient(deletingCat("other"))
+				_, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finalized).To(BeEmpty())
+				Expect(reconciled).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should retry the finalizer removal on conflicts", func() {
+				conflicts := 0
+				cl := newInterceptedFakeClient(
+					interceptor.Funcs{
+						Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+							if conflicts < 2 {
+								conflicts++
+								return apierrors.NewConflict(v1.GroupVersion.WithResource("cats").GroupResource(), obj.GetName(), errors.New("stale"))
+							}
+							return c.Patch(ctx, obj, patch, opts...)
+						},
+					},
+					deletingCat(finalizer),
+				)
+				_, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conflicts).To(Equal(2))
+
+				var cat v1.Cat
+				err = cl.Get(context.TODO(), req.NamespacedName, &cat)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue(), "the object should be gone once its last finalizer is removed")
+			},
+		)
+
+		It(
+			"should treat an object gone during removal as finalized", func() {
+				cl := newInterceptedFakeClient(
+					interceptor.Funcs{
+						Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+							return apierrors.NewNotFound(v1.GroupVersion.WithResource("cats").GroupResource(), obj.GetName())
+						},
+					},
+					deletingCat(finalizer),
+				)
+				_, err := newReconciler(cl).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finalized).To(Equal([]string{"tom"}))
+			},
+		)
+
+		It(
+			"should override OnDeleting without touching the caller's options", func() {
+				var custom bool
+				opts := make([]freconcile.ForOption[*v1.Cat], 1, 2)
+				opts[0] = freconcile.OnDeleting(func(*v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+					custom = true
+					return RIOE.Right[fclient.Env, error](reconcile.Result{})
+				})
+				r := freconcile.WithFinalizer[v1.Cat](finalizer, nil, func(cat *v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+					finalized = append(finalized, cat.Name)
+					return RIOE.Right[fclient.Env, error](reconcile.Result{})
+				}, opts...)
+				Expect(opts[:2][1]).To(BeNil())
+
+				_, err := freconcile.Func(newFakeClient(deletingCat(finalizer)), r).Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finalized).To(Equal([]string{"tom"}))
+				Expect(custom).To(BeFalse())
+			},
+		)
+	},
+)
@@ -0,0 +1,67 @@
+package freconcile
+
+import (
+	F "github.com/IBM/fp-go/function"
+	O "github.com/IBM/fp-go/option"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ObjectReconciler is a functional reconciler for an object that has already been fetched.
+type ObjectReconciler[OP any] = func(OP) fclient.ReaderIOEither[reconcile.Result]
+
+// ForOption configures [For] on objects of type OP, so that an option for another type does not compile.
+type ForOption[OP any] func(*forOptions[OP])
+
+type forOptions[OP any] struct {
+	onGone     Reconciler
+	onDeleting ObjectReconciler[OP]
+}
+
+// OnGone sets the reconciler run when the requested object no longer exists,
+// e.g. to clean up external state. By default nothing is done.
+func OnGone[OP any](f Reconciler) ForOption[OP] {
+	return func(o *forOptions[OP]) {
+		o.onGone = f
+	}
+}
+
+// OnDeleting sets the reconciler run for objects that are being deleted. By default they are skipped.
+func OnDeleting[OP any](f ObjectReconciler[OP]) ForOption[OP] {
+	return func(o *forOptions[OP]) {
+		o.onDeleting = f
+	}
+}
+
+// For creates a Reconciler that fetches the requested object and hands it to f.
+//
+// The object is fetched with [fclient.GetOption]. When it no longer exists, the [OnGone] reconciler is run instead.
+// Objects being deleted are skipped unless [OnDeleting] is given, so f only sees live objects.
+func For[T any, OP fclient.ObjectPointer[T]](f ObjectReconciler[OP], opts ...ForOption[OP]) Reconciler {
+	o := forOptions[OP]{onGone: done}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(req reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+		return F.Pipe1(
+			fclient.GetOption[T, OP](fclient.ToGetParams(req.NamespacedName)),
+			RIOE.Chain(O.Fold(
+				func() fclient.ReaderIOEither[reconcile.Result] { return o.onGone(req) },
+				func(obj OP) fclient.ReaderIOEither[reconcile.Result] {
+					if !obj.GetDeletionTimestamp().IsZero() {
+						if o.onDeleting == nil {
+							return done(req)
+						}
+						return o.onDeleting(obj)
+					}
+					return f(obj)
+				},
+			)),
+		)
+	}
+}
+
+func done(reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+	return RIOE.Right[fclient.Env, error](reconcile.Result{})
+}
@@ -0,0 +1,91 @@
+package freconcile_test
+
+import (
+	"context"
+	"time"
+
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe(
+	"For", func() {
+
+		var seen []string
+
+		recordName := func(cat *v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+			seen = append(seen, cat.Name)
+			return RIOE.Right[fclient.Env, error](reconcile.Result{RequeueAfter: time.Minute})
+		}
+
+		requestFor := func(name string) reconcile.Request {
+			return reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+		}
+
+		BeforeEach(
+			func() {
+				seen = nil
+			},
+		)
+
+		It(
+			"should hand the fetched object to the reconciler", func() {
+				cl := newFakeClient(&v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default"}})
+				r := freconcile.Func(cl, freconcile.For[v1.Cat](recordName))
+				result, err := r.Reconcile(context.TODO(), requestFor("tom"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(time.Minute))
+				Expect(seen).To(Equal([]string{"tom"}))
+			},
+		)
+
+		It(
+			"should skip objects being deleted", func() {
+				now := metav1.Now()
+				cl := newFakeClient(&v1.Cat{ObjectMeta: metav1.ObjectMeta{
+					Name:              "tom",
+					Namespace:         "default",
+					DeletionTimestamp: &now,
+					Finalizers:        []string{"test.appthrust.com/finalizer"},
+				}})
+				r := freconcile.Func(cl, freconcile.For[v1.Cat](recordName))
+				result, err := r.Reconcile(context.TODO(), requestFor("tom"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{}))
+				Expect(seen).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should do nothing when the object is gone", func() {
+				r := freconcile.Func(newFakeClient(), freconcile.For[v1.Cat](recordName))
+				result, err := r.Reconcile(context.TODO(), requestFor("tom"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{}))
+				Expect(seen).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should run the OnGone hook when the object is gone", func() {
+				var gone []reconcile.Request
+				onGone := func(req reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+					gone = append(gone, req)
+					return RIOE.Right[fclient.Env, error](reconcile.Result{})
+				}
+				r := freconcile.Func(newFakeClient(), freconcile.For[v1.Cat](recordName, freconcile.OnGone[*v1.Cat](onGone)))
+				_, err := r.Reconcile(context.TODO(), requestFor("tom"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gone).To(Equal([]reconcile.Request{requestFor("tom")}))
+				Expect(seen).To(BeEmpty())
+			},
+		)
+	},
+)
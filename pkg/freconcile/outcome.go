@@ -0,0 +1,190 @@
+package freconcile
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	M "github.com/IBM/fp-go/monoid"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// outcomeKind enumerates the cases of Outcome, ordered by precedence when merging.
+type outcomeKind int
+
+const (
+	outcomeDone outcomeKind = iota
+	outcomeRequeueAfter
+	outcomeRequeue
+	outcomeRetry
+	outcomeTerminal
+)
+
+// Outcome is the result of a reconcile step: Done, Requeue, RequeueAfter, Retry or Terminal.
+//
+// Unlike the pair of [reconcile.Result] and error, every Outcome is exactly one of these cases.
+// Outcomes of several steps are merged with [OutcomeMonoid].
+type Outcome struct {
+	kind  outcomeKind
+	after time.Duration
+	err   error
+}
+
+// ErrNoOutcomeError is the error of a Retry or Terminal outcome built from a nil error,
+// so that such an outcome is still reported as a failure.
+var ErrNoOutcomeError = errors.New("freconcile: failed outcome without an error")
+
+// Done is the Outcome of a reconcile that has nothing left to do.
+func Done() Outcome {
+	return Outcome{kind: outcomeDone}
+}
+
+// Requeue is the Outcome of a reconcile that asks for a rate-limited requeue.
+func Requeue() Outcome {
+	return Outcome{kind: outcomeRequeue}
+}
+
+// RequeueAfter is the Outcome of a reconcile that asks to be run again after d.
+func RequeueAfter(d time.Duration) Outcome {
+	if d <= 0 {
+		return Requeue()
+	}
+	return Outcome{kind: outcomeRequeueAfter, after: d}
+}
+
+// Retry is the Outcome of a reconcile that failed with err and should be retried with backoff.
+// A nil err is replaced with [ErrNoOutcomeError].
+func Retry(err error) Outcome {
+	return Outcome{kind: outcomeRetry, err: orNoOutcomeError(err)}
+}
+
+// Terminal is the Outcome of a reconcile that failed with err and must not be retried until the object changes.
+// A nil err is replaced with [ErrNoOutcomeError].
+func Terminal(err error) Outcome {
+	return Outcome{kind: outcomeTerminal, err: orNoOutcomeError(err)}
+}
+
+// IsDone reports whether o is Done.
+func (o Outcome) IsDone() bool {
+	return o.kind == outcomeDone
+}
+
+// Err returns the error of a Retry or Terminal outcome, or nil.
+func (o Outcome) Err() error {
+	return o.err
+}
+
+// String implements fmt.Stringer.
+func (o Outcome) String() string {
+	switch o.kind {
+	case outcomeRequeue:
+		return "Requeue"
+	case outcomeRequeueAfter:
+		return fmt.Sprintf("RequeueAfter(%s)", o.after)
+	case outcomeRetry:
+		return fmt.Sprintf("Retry(%v)", o.err)
+	case outcomeTerminal:
+		return fmt.Sprintf("Terminal(%v)", o.err)
+	default:
+		return "Done"
+	}
+}
+
+// Result converts o to the values returned by [reconcile.Reconciler].
+//
+// Terminal errors are wrapped with [reconcile.TerminalError].
+func (o Outcome) Result() (reconcile.Result, error) {
+	switch o.kind {
+	case outcomeRequeue:
+		return reconcile.Result{Requeue: true}, nil //nolint:staticcheck // Requeue is the rate-limited requeue this case models.
+	case outcomeRequeueAfter:
+		return reconcile.Result{RequeueAfter: o.after}, nil
+	case outcomeRetry:
+		return reconcile.Result{}, o.err
+	case outcomeTerminal:
+		if isTerminal(o.err) {
+			return reconcile.Result{}, o.err
+		}
+		return reconcile.Result{}, reconcile.TerminalError(o.err)
+	default:
+		return reconcile.Result{}, nil
+	}
+}
+
+// FromResult converts the values returned by [reconcile.Reconciler] to an Outcome.
+//
+// Errors wrapped with [reconcile.TerminalError] become Terminal, other errors become Retry.
+func FromResult(result reconcile.Result, err error) Outcome {
+	switch {
+	case isTerminal(err):
+		if inner := errors.Unwrap(err); inner != nil && !isTerminal(inner) {
+			// err is the terminal wrapper itself; keep only the cause.
+			return Terminal(inner)
+		}
+		return Terminal(err)
+	case err != nil:
+		return Retry(err)
+	case result.RequeueAfter > 0:
+		return RequeueAfter(result.RequeueAfter)
+	case result.Requeue: //nolint:staticcheck // Requeue is still honoured by controller-runtime.
+		return Requeue()
+	default:
+		return Done()
+	}
+}
+
+// ConcatOutcomes merges the outcomes of two reconcile steps.
+//
+// Terminal dominates Retry, which dominates Requeue, which dominates RequeueAfter, which dominates Done.
+// Between two RequeueAfter outcomes the shorter delay wins, and the errors of two failures are joined.
+func ConcatOutcomes(a, b Outcome) Outcome {
+	switch {
+	case a.kind > b.kind:
+		return a
+	case a.kind < b.kind:
+		return b
+	case a.kind == outcomeRequeueAfter:
+		return RequeueAfter(min(a.after, b.after))
+	case a.kind == outcomeRetry || a.kind == outcomeTerminal:
+		return Outcome{kind: a.kind, err: errors.Join(a.err, b.err)}
+	default:
+		return a
+	}
+}
+
+// OutcomeMonoid merges outcomes with [ConcatOutcomes]; Done is its identity.
+var OutcomeMonoid = M.MakeMonoid(ConcatOutcomes, Done())
+
+// ToResult converts a pipeline yielding an Outcome into one yielding a [reconcile.Result].
+//
+// Retry and Terminal outcomes become Lefts, so the result can be used with [Func].
+func ToResult(rioe fclient.ReaderIOEither[Outcome]) fclient.ReaderIOEither[reconcile.Result] {
+	return RIOE.ChainEitherK[fclient.Env](func(o Outcome) fclient.Either[reconcile.Result] {
+		return ET.TryCatchError(o.Result())
+	})(rioe)
+}
+
+// ToOutcome converts a pipeline yielding a [reconcile.Result] into one yielding an Outcome.
+//
+// Lefts become Retry or Terminal outcomes as described in [FromResult], so the returned pipeline never fails.
+func ToOutcome(rioe fclient.ReaderIOEither[reconcile.Result]) fclient.ReaderIOEither[Outcome] {
+	return func(env fclient.Env) fclient.IOEither[Outcome] {
+		return func() fclient.Either[Outcome] {
+			return ET.Right[error](FromResult(ET.UnwrapError(rioe(env)())))
+		}
+	}
+}
+
+func orNoOutcomeError(err error) error {
+	if err == nil {
+		return ErrNoOutcomeError
+	}
+	return err
+}
+
+func isTerminal(err error) bool {
+	return errors.Is(err, reconcile.TerminalError(nil))
+}
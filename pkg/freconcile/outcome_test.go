@@ -0,0 +1,92 @@
+package freconcile_test
+
+import (
+	"errors"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	M "github.com/IBM/fp-go/monoid"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe(
+	"Outcome", func() {
+
+		errA := errors.New("a")
+		errB := errors.New("b")
+
+		DescribeTable(
+			"ConcatOutcomes",
+			func(a, b freconcile.Outcome, expected string) {
+				Expect(freconcile.ConcatOutcomes(a, b).String()).To(Equal(expected))
+				Expect(freconcile.ConcatOutcomes(b, a).String()).To(Equal(expected))
+			},
+			Entry("Done is the identity", freconcile.Done(), freconcile.RequeueAfter(time.Second), "RequeueAfter(1s)"),
+			Entry("the minimum RequeueAfter wins", freconcile.RequeueAfter(time.Minute), freconcile.RequeueAfter(time.Second), "RequeueAfter(1s)"),
+			Entry("Requeue beats RequeueAfter", freconcile.Requeue(), freconcile.RequeueAfter(time.Second), "Requeue"),
+			Entry("Retry beats Requeue", freconcile.Retry(errA), freconcile.Requeue(), "Retry(a)"),
+			Entry("Terminal dominates", freconcile.Terminal(errA), freconcile.Retry(errB), "Terminal(a)"),
+		)
+
+		It(
+			"should join the errors of failures of the same kind", func() {
+				merged := M.ConcatAll(freconcile.OutcomeMonoid)([]freconcile.Outcome{
+					freconcile.Retry(errA),
+					freconcile.RequeueAfter(time.Second),
+					freconcile.Retry(errB),
+				})
+				Expect(merged.Err()).To(MatchError(errA))
+				Expect(merged.Err()).To(MatchError(errB))
+			},
+		)
+
+		DescribeTable(
+			"Result",
+			func(o freconcile.Outcome, expected reconcile.Result, expectTerminal bool, expectErr error) {
+				result, err := o.Result()
+				Expect(result).To(Equal(expected))
+				if expectErr == nil {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				Expect(err).To(MatchError(expectErr))
+				Expect(errors.Is(err, reconcile.TerminalError(nil))).To(Equal(expectTerminal))
+				Expect(freconcile.FromResult(result, err).String()).To(Equal(o.String()))
+			},
+			Entry("Done", freconcile.Done(), reconcile.Result{}, false, nil),
+			Entry("Requeue", freconcile.Requeue(), reconcile.Result{Requeue: true}, false, nil),
+			Entry("RequeueAfter", freconcile.RequeueAfter(time.Second), reconcile.Result{RequeueAfter: time.Second}, false, nil),
+			Entry("Retry", freconcile.Retry(errA), reconcile.Result{}, false, errA),
+			Entry("Terminal", freconcile.Terminal(errA), reconcile.Result{}, true, errA),
+		)
+
+		It(
+			"should report failed outcomes without an error as failures", func() {
+				_, err := freconcile.Retry(nil).Result()
+				Expect(err).To(MatchError(freconcile.ErrNoOutcomeError))
+				_, err = freconcile.Terminal(nil).Result()
+				Expect(err).To(MatchError(freconcile.ErrNoOutcomeError))
+				Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+			},
+		)
+
+		It(
+			"should convert between Outcome and Result pipelines", func() {
+				env := fclient.Env{}
+				result := freconcile.ToResult(RIOE.Right[fclient.Env, error](freconcile.Terminal(errA)))(env)()
+				_, err := ET.UnwrapError(result)
+				Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+
+				outcome := freconcile.ToOutcome(RIOE.Left[fclient.Env, reconcile.Result](errB))(env)()
+				o, err := ET.UnwrapError(outcome)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(o.String()).To(Equal("Retry(b)"))
+			},
+		)
+	},
+)
@@ -0,0 +1,113 @@
+package freconcile
+
+import (
+	"fmt"
+	"time"
+
+	F "github.com/IBM/fp-go/function"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultPauseAnnotation is the annotation honoured by [Pausable] unless [WithPauseAnnotation] is given.
+const DefaultPauseAnnotation = "fcr.appthrust.com/paused"
+
+// ConditionPaused is the condition type maintained by [Pausable].
+const ConditionPaused = "Paused"
+
+// Condition and event reasons set by [Pausable].
+const (
+	ReasonPaused  = "Paused"
+	ReasonResumed = "Resumed"
+)
+
+// PauseOption configures [Pausable].
+type PauseOption func(*pauseOptions)
+
+type pauseOptions struct {
+	annotation string
+}
+
+// WithPauseAnnotation makes [Pausable] honour the annotation name instead of [DefaultPauseAnnotation].
+func WithPauseAnnotation(name string) PauseOption {
+	return func(o *pauseOptions) {
+		o.annotation = name
+	}
+}
+
+// Pausable wraps an ObjectReconciler so that reconciling an object can be frozen with an annotation.
+//
+// The object is paused while the annotation is "true", or an RFC 3339 timestamp in the future.
+// Any other value, including an expired timestamp, is ignored. While paused, f does not run, the
+// Paused condition is set to True and, when the object was not paused before, a Paused event is recorded
+// with Env.Recorder. A pause with an expiry requeues the object for when it expires.
+//
+// Once the annotation is removed or expires, the Paused condition is set to False, a Resumed event is
+// recorded, and f runs again. The status is persisted with [PatchStatus].
+func Pausable[T any, OP interface {
+	fclient.ObjectPointer[T]
+	ConditionedObject
+}](f ObjectReconciler[OP], opts ...PauseOption) ObjectReconciler[OP] {
+	o := pauseOptions{annotation: DefaultPauseAnnotation}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return PatchStatus[T, OP](func(obj OP) fclient.ReaderIOEither[reconcile.Result] {
+		wasPaused := apimeta.IsStatusConditionTrue(obj.GetConditions(), ConditionPaused)
+		until, paused := pausedUntil(obj.GetAnnotations()[o.annotation], time.Now())
+		if !paused {
+			if !wasPaused {
+				return f(obj)
+			}
+			message := fmt.Sprintf("annotation %s was removed or expired", o.annotation)
+			setPausedCondition(obj, metav1.ConditionFalse, ReasonResumed, message)
+			return F.Pipe1(
+				fclient.Event(obj, corev1.EventTypeNormal, ReasonResumed, message),
+				RIOE.Chain(func(fclient.Unit) fclient.ReaderIOEither[reconcile.Result] { return f(obj) }),
+			)
+		}
+		result := reconcile.Result{}
+		message := fmt.Sprintf("reconcile paused by annotation %s", o.annotation)
+		if !until.IsZero() {
+			result.RequeueAfter = time.Until(until)
+			message = fmt.Sprintf("%s until %s", message, until.Format(time.RFC3339))
+		}
+		setPausedCondition(obj, metav1.ConditionTrue, ReasonPaused, message)
+		if wasPaused {
+			return RIOE.Right[fclient.Env, error](result)
+		}
+		return F.Pipe1(
+			fclient.Event(obj, corev1.EventTypeNormal, ReasonPaused, message),
+			RIOE.MapTo[fclient.Env, error, fclient.Unit](result),
+		)
+	})
+}
+
+// pausedUntil interprets the value of a pause annotation at now.
+// It returns the expiry, zero for an indefinite pause, and whether the object is paused.
+func pausedUntil(value string, now time.Time) (time.Time, bool) {
+	if value == "true" {
+		return time.Time{}, true
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil || !until.After(now) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+func setPausedCondition(obj ConditionedObject, status metav1.ConditionStatus, reason, message string) {
+	conditions := obj.GetConditions()
+	apimeta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               ConditionPaused,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+	})
+	obj.SetConditions(conditions)
+}
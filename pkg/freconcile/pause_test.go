@@ -0,0 +1,120 @@
+package freconcile_test
+
+import (
+	"context"
+	"time"
+
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe(
+	"Pausable", func() {
+
+		var cl client.Client
+		var recorder *record.FakeRecorder
+		var reconciled int
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+
+		catWith := func(annotations map[string]string) *v1.Cat {
+			return &v1.Cat{ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default", Annotations: annotations}}
+		}
+
+		reconcileCat := func(opts ...freconcile.PauseOption) reconcile.Result {
+			inner := freconcile.For[v1.Cat](freconcile.Pausable(func(*v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+				reconciled++
+				return RIOE.Right[fclient.Env, error](reconcile.Result{})
+			}, opts...))
+			r := freconcile.Func(cl, func(req reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+				return fclient.WithRecorder[reconcile.Result](recorder)(inner(req))
+			})
+			result, err := r.Reconcile(context.TODO(), req)
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+
+		getCat := func() *v1.Cat {
+			var cat v1.Cat
+			Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+			return &cat
+		}
+
+		BeforeEach(
+			func() {
+				recorder = record.NewFakeRecorder(10)
+				reconciled = 0
+			},
+		)
+
+		It(
+			"should reconcile objects that are not paused", func() {
+				cl = newFakeClient(catWith(nil))
+				reconcileCat()
+				Expect(reconciled).To(Equal(1))
+				Expect(apimeta.FindStatusCondition(getCat().GetConditions(), freconcile.ConditionPaused)).To(BeNil())
+				Expect(recorder.Events).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should short-circuit a paused object and report it once", func() {
+				cl = newFakeClient(catWith(map[string]string{freconcile.DefaultPauseAnnotation: "true"}))
+				Expect(reconcileCat()).To(Equal(reconcile.Result{}))
+				reconcileCat()
+				Expect(reconciled).To(BeZero())
+				Expect(apimeta.IsStatusConditionTrue(getCat().GetConditions(), freconcile.ConditionPaused)).To(BeTrue())
+				Expect(recorder.Events).To(HaveLen(1))
+				Expect(<-recorder.Events).To(HavePrefix("Normal Paused"))
+			},
+		)
+
+		It(
+			"should requeue a pause with an expiry when it expires", func() {
+				until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+				cl = newFakeClient(catWith(map[string]string{"ops.example.com/freeze": until}))
+				result := reconcileCat(freconcile.WithPauseAnnotation("ops.example.com/freeze"))
+				Expect(reconciled).To(BeZero())
+				Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+				Expect(apimeta.FindStatusCondition(getCat().GetConditions(), freconcile.ConditionPaused).Message).To(ContainSubstring(until))
+			},
+		)
+
+		It(
+			"should ignore an expired pause", func() {
+				until := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+				cl = newFakeClient(catWith(map[string]string{freconcile.DefaultPauseAnnotation: until}))
+				reconcileCat()
+				Expect(reconciled).To(Equal(1))
+			},
+		)
+
+		It(
+			"should resume once the annotation is removed", func() {
+				cl = newFakeClient(catWith(map[string]string{freconcile.DefaultPauseAnnotation: "true"}))
+				reconcileCat()
+				cat := getCat()
+				cat.Annotations = nil
+				Expect(cl.Update(context.TODO(), cat)).To(Succeed())
+
+				reconcileCat()
+				Expect(reconciled).To(Equal(1))
+				paused := apimeta.FindStatusCondition(getCat().GetConditions(), freconcile.ConditionPaused)
+				Expect(paused.Status).To(Equal(metav1.ConditionFalse))
+				Expect(paused.Reason).To(Equal(freconcile.ReasonResumed))
+				Expect(<-recorder.Events).To(HavePrefix("Normal Paused"))
+				Expect(<-recorder.Events).To(HavePrefix("Normal Resumed"))
+			},
+		)
+	},
+)
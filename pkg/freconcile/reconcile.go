@@ -0,0 +1,25 @@
+// Package freconcile provides functional reconcilers built from [fclient] pipelines and adapted to controller-runtime's [reconcile.Reconciler].
+package freconcile
+
+import (
+	"context"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reconciler is a functional reconciler mapping a request to a pipeline that yields a [reconcile.Result].
+type Reconciler = func(reconcile.Request) fclient.ReaderIOEither[reconcile.Result]
+
+// Func adapts a functional reconciler to [reconcile.Reconciler].
+//
+// For every request, the Env is built from the incoming context and the given client,
+// the pipeline is evaluated, and a Left is returned as the reconcile error.
+func Func(cl client.Client, f Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		env := fclient.Env{Ctx: ctx, Client: cl}
+		return ET.UnwrapError(f(req)(env)())
+	})
+}
@@ -0,0 +1,89 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFreconcile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Freconcile Suite")
+}
+
+// newFakeClient returns a fake client aware of the test API types and holding objs.
+func newFakeClient(objs ...client.Object) client.WithWatch {
+	return newFakeClientBuilder(objs...).Build()
+}
+
+// newInterceptedFakeClient is like newFakeClient but routes calls through funcs.
+func newInterceptedFakeClient(funcs interceptor.Funcs, objs ...client.Object) client.WithWatch {
+	return newFakeClientBuilder(objs...).WithInterceptorFuncs(funcs).Build()
+}
+
+func newFakeClientBuilder(objs ...client.Object) *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1.Cat{})
+}
+
+var _ = Describe(
+	"Func", func() {
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+
+		It(
+			"should build the Env from the context and client", func() {
+				type key struct{}
+				cl := newFakeClient()
+				var seen fclient.Env
+				var seenReq reconcile.Request
+				r := freconcile.Func(cl, func(req reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+					seenReq = req
+					return func(env fclient.Env) fclient.IOEither[reconcile.Result] {
+						seen = env
+						return RIOE.Right[fclient.Env, error](reconcile.Result{RequeueAfter: time.Minute})(env)
+					}
+				})
+				ctx := context.WithValue(context.TODO(), key{}, "value")
+				result, err := r.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+				Expect(seenReq).To(Equal(req))
+				Expect(seen.Client).To(BeIdenticalTo(cl))
+				Expect(seen.Ctx.Value(key{})).To(Equal("value"))
+			},
+		)
+
+		It(
+			"should return a Left as the reconcile error", func() {
+				boom := errors.New("boom")
+				r := freconcile.Func(newFakeClient(), func(reconcile.Request) fclient.ReaderIOEither[reconcile.Result] {
+					return RIOE.Left[fclient.Env, reconcile.Result](boom)
+				})
+				result, err := r.Reconcile(context.TODO(), req)
+				Expect(err).To(MatchError(boom))
+				Expect(result).To(Equal(reconcile.Result{}))
+			},
+		)
+	},
+)
@@ -0,0 +1,78 @@
+package freconcile
+
+import (
+	"errors"
+	"fmt"
+
+	ET "github.com/IBM/fp-go/either"
+	IOE "github.com/IBM/fp-go/ioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// PatchStatus wraps an ObjectReconciler so that the status it computes is persisted by the framework.
+//
+// The object is snapshotted before f runs; f is free to mutate the object's status in place.
+// Afterwards the old and new status are compared semantically and, only when they differ,
+// the status is written with [fclient.StatusPatch] using a merge patch against the snapshot.
+// The status is persisted even when f fails, so that error reporting in the status is not lost;
+// in that case the error of f is returned, joined with the patch error if any.
+func PatchStatus[T any, OP fclient.ObjectPointer[T]](f ObjectReconciler[OP]) ObjectReconciler[OP] {
+	return func(obj OP) fclient.ReaderIOEither[reconcile.Result] {
+		return func(env fclient.Env) fclient.IOEither[reconcile.Result] {
+			return func() fclient.Either[reconcile.Result] {
+				before, ok := obj.DeepCopyObject().(OP)
+				if !ok {
+					return ET.Left[reconcile.Result](fmt.Errorf("failed to snapshot %T", obj))
+				}
+				result, reconcileErr := ET.UnwrapError(f(obj)(env)())
+				_, patchErr := ET.UnwrapError(patchStatusIfChanged(before, obj)(env)())
+				if reconcileErr != nil {
+					return ET.Left[reconcile.Result](errors.Join(reconcileErr, patchErr))
+				}
+				if patchErr != nil {
+					return ET.Left[reconcile.Result](patchErr)
+				}
+				return ET.Right[error](result)
+			}
+		}
+	}
+}
+
+// patchStatusIfChanged merge-patches the status of after against before when the two statuses differ.
+func patchStatusIfChanged(before, after client.Object) fclient.ReaderIOEither[fclient.Unit] {
+	return func(env fclient.Env) fclient.IOEither[fclient.Unit] {
+		changed, err := statusChanged(before, after)
+		if err != nil {
+			return IOE.Left[fclient.Unit](err)
+		}
+		if !changed {
+			return IOE.Right[error](fclient.UnitValue)
+		}
+		return fclient.StatusPatch(fclient.ToStatusPatchParams(after, client.MergeFrom(before)))(env)
+	}
+}
+
+// statusChanged reports whether the "status" fields of before and after differ semantically.
+func statusChanged(before, after runtime.Object) (bool, error) {
+	beforeStatus, err := statusOf(before)
+	if err != nil {
+		return false, err
+	}
+	afterStatus, err := statusOf(after)
+	if err != nil {
+		return false, err
+	}
+	return !equality.Semantic.DeepEqual(beforeStatus, afterStatus), nil
+}
+
+func statusOf(obj runtime.Object) (any, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T to unstructured: %w", obj, err)
+	}
+	return u["status"], nil
+}
@@ -0,0 +1,92 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe(
+	"PatchStatus", func() {
+
+		var cl client.Client
+		var statusPatches int
+
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "tom", Namespace: "default"}}
+
+		BeforeEach(
+			func() {
+				statusPatches = 0
+				cl = newInterceptedFakeClient(
+					interceptor.Funcs{
+						SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+							statusPatches++
+							return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+						},
+					},
+					&v1.Cat{
+						ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default"},
+						Status:     &v1.CatStatus{Sleepy: false},
+					},
+				)
+			},
+		)
+
+		setSleepy := func(sleepy bool, err error) freconcile.ObjectReconciler[*v1.Cat] {
+			return func(cat *v1.Cat) fclient.ReaderIOEither[reconcile.Result] {
+				cat.Status.Sleepy = sleepy
+				if err != nil {
+					return RIOE.Left[fclient.Env, reconcile.Result](err)
+				}
+				return RIOE.Right[fclient.Env, error](reconcile.Result{})
+			}
+		}
+
+		getCat := func() *v1.Cat {
+			var cat v1.Cat
+			Expect(cl.Get(context.TODO(), req.NamespacedName, &cat)).To(Succeed())
+			return &cat
+		}
+
+		It(
+			"should patch the status when it changed", func() {
+				r := freconcile.Func(cl, freconcile.For[v1.Cat](freconcile.PatchStatus(setSleepy(true, nil))))
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(statusPatches).To(Equal(1))
+				Expect(getCat().Status.Sleepy).To(BeTrue())
+			},
+		)
+
+		It(
+			"should not write when the status is unchanged", func() {
+				r := freconcile.Func(cl, freconcile.For[v1.Cat](freconcile.PatchStatus(setSleepy(false, nil))))
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(statusPatches).To(BeZero())
+			},
+		)
+
+		It(
+			"should persist the status and return the error when the reconciler fails", func() {
+				boom := errors.New("boom")
+				r := freconcile.Func(cl, freconcile.For[v1.Cat](freconcile.PatchStatus(setSleepy(true, boom))))
+				_, err := r.Reconcile(context.TODO(), req)
+				Expect(err).To(MatchError(boom))
+				Expect(statusPatches).To(Equal(1))
+				Expect(getCat().Status.Sleepy).To(BeTrue())
+			},
+		)
+	},
+)
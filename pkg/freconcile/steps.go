@@ -0,0 +1,149 @@
+package freconcile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// stepDuration observes the duration of every step run by [Steps], by step name and result.
+var stepDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "fcr_reconcile_step_duration_seconds",
+		Help: "Duration of named reconcile steps, by step and result.",
+	},
+	[]string{"step", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(stepDuration)
+}
+
+// NamedStep is one named operation of a [Steps] pipeline.
+type NamedStep struct {
+	name string
+	op   fclient.ReaderIOEither[fclient.Unit]
+}
+
+// Step names op so that [Steps] can report on it.
+func Step(name string, op fclient.ReaderIOEither[fclient.Unit]) NamedStep {
+	return NamedStep{name: name, op: op}
+}
+
+// StepRecord is what [Steps] recorded about one step that ran.
+type StepRecord struct {
+	Name     string
+	Duration time.Duration
+	// Err is the error the step failed with, or nil if it succeeded.
+	Err error
+}
+
+// StepReport lists the steps run by [Steps], in order. Steps after a failed one are not run and not listed.
+type StepReport struct {
+	Steps []StepRecord
+}
+
+// Failed returns the record of the step that failed, if any.
+func (r StepReport) Failed() (StepRecord, bool) {
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			return s, true
+		}
+	}
+	return StepRecord{}, false
+}
+
+// Summary renders the report compactly, e.g. "ensure-secret ok, ensure-deployment failed".
+//
+// Durations are left out so that the summary of an unchanged outcome is stable across reconciles; they are
+// logged and observed in the histogram by [Steps] instead.
+func (r StepReport) Summary() string {
+	parts := make([]string, 0, len(r.Steps))
+	for _, s := range r.Steps {
+		result := "ok"
+		if s.Err != nil {
+			result = "failed"
+		}
+		parts = append(parts, s.Name+" "+result)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// StepError is returned by [Steps] when a step fails. It carries the report up to and including the failed step.
+type StepError struct {
+	Step   string
+	Err    error
+	Report StepReport
+}
+
+// Error implements the error interface.
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %q: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the error of the failed step.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// ReportOf returns the StepReport carried by a [StepError] in err's chain.
+func ReportOf(err error) (StepReport, bool) {
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		return stepErr.Report, true
+	}
+	return StepReport{}, false
+}
+
+// Steps runs steps in order and stops at the first one that fails.
+//
+// Each step is logged with its duration through the logger in the Env's context, and observed in the
+// fcr_reconcile_step_duration_seconds histogram. On success the report of all steps is returned; on failure
+// a [StepError] naming the failed step is returned.
+func Steps(steps ...NamedStep) fclient.ReaderIOEither[StepReport] {
+	return func(env fclient.Env) fclient.IOEither[StepReport] {
+		return func() fclient.Either[StepReport] {
+			logger := log.FromContext(env.Ctx)
+			report := StepReport{Steps: make([]StepRecord, 0, len(steps))}
+			for _, step := range steps {
+				start := time.Now()
+				_, err := ET.UnwrapError(step.op(env)())
+				record := StepRecord{Name: step.name, Duration: time.Since(start), Err: err}
+				report.Steps = append(report.Steps, record)
+				if err != nil {
+					stepDuration.WithLabelValues(step.name, "error").Observe(record.Duration.Seconds())
+					logger.Error(err, "Reconcile step failed", "step", step.name, "duration", record.Duration)
+					return ET.Left[StepReport](error(&StepError{Step: step.name, Err: err, Report: report}))
+				}
+				stepDuration.WithLabelValues(step.name, "success").Observe(record.Duration.Seconds())
+				logger.V(1).Info("Reconcile step finished", "step", step.name, "duration", record.Duration)
+			}
+			return ET.Right[error](report)
+		}
+	}
+}
+
+// StepCondition builds a condition of conditionType summarising report, ready for apimeta.SetStatusCondition.
+//
+// The condition is True with reason Reconciled when every step succeeded, and False with reason Failed otherwise.
+func StepCondition(conditionType string, report StepReport) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonReconciled,
+		Message: report.Summary(),
+	}
+	if _, failed := report.Failed(); failed {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonFailed
+	}
+	return condition
+}
@@ -0,0 +1,91 @@
+package freconcile_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ET "github.com/IBM/fp-go/either"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/freconcile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe(
+	"Steps", func() {
+
+		var ran []string
+
+		env := fclient.Env{Ctx: context.TODO()}
+
+		ok := func(name string) freconcile.NamedStep {
+			return freconcile.Step(name, func(fclient.Env) fclient.IOEither[fclient.Unit] {
+				return func() fclient.Either[fclient.Unit] {
+					ran = append(ran, name)
+					return ET.Right[error](fclient.UnitValue)
+				}
+			})
+		}
+
+		BeforeEach(
+			func() {
+				ran = nil
+			},
+		)
+
+		It(
+			"should run every step in order and report them", func() {
+				report, err := ET.UnwrapError(freconcile.Steps(ok("ensure-secret"), ok("ensure-deployment"))(env)())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ran).To(Equal([]string{"ensure-secret", "ensure-deployment"}))
+				Expect(report.Steps).To(HaveLen(2))
+				Expect(report.Steps[0].Name).To(Equal("ensure-secret"))
+				Expect(report.Summary()).To(Equal("ensure-secret ok, ensure-deployment ok"))
+				_, failed := report.Failed()
+				Expect(failed).To(BeFalse())
+			},
+		)
+
+		It(
+			"should stop at the first failing step", func() {
+				boom := errors.New("secret store unreachable")
+				_, err := ET.UnwrapError(freconcile.Steps(
+					ok("ensure-namespace"),
+					freconcile.Step("ensure-secret", RIOE.Left[fclient.Env, fclient.Unit](boom)),
+					ok("ensure-deployment"),
+				)(env)())
+				Expect(err).To(MatchError(boom))
+				Expect(err.Error()).To(Equal(`step "ensure-secret": secret store unreachable`))
+				Expect(ran).To(Equal([]string{"ensure-namespace"}))
+
+				report, found := freconcile.ReportOf(err)
+				Expect(found).To(BeTrue())
+				Expect(report.Steps).To(HaveLen(2))
+				failed, _ := report.Failed()
+				Expect(failed.Name).To(Equal("ensure-secret"))
+				Expect(report.Summary()).To(Equal("ensure-namespace ok, ensure-secret failed"))
+			},
+		)
+
+		It(
+			"should summarise a report as a condition", func() {
+				report := freconcile.StepReport{Steps: []freconcile.StepRecord{
+					{Name: "a", Duration: time.Second},
+					{Name: "b", Duration: time.Millisecond, Err: errors.New("b")},
+				}}
+				condition := freconcile.StepCondition("Provisioned", report)
+				Expect(condition.Type).To(Equal("Provisioned"))
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(freconcile.ReasonFailed))
+				Expect(condition.Message).To(Equal("a ok, b failed"), "durations must not make the condition change on every reconcile")
+
+				condition = freconcile.StepCondition("Provisioned", freconcile.StepReport{Steps: report.Steps[:1]})
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(freconcile.ReasonReconciled))
+			},
+		)
+	},
+)
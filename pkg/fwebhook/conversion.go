@@ -0,0 +1,187 @@
+package fwebhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ConvertPath is the path [Converting] serves conversion reviews at, as controller-runtime does.
+const ConvertPath = "/convert"
+
+// Convert is a pure conversion from From to To. It must not mutate its input.
+type Convert[From, To any] func(From) To
+
+// SpokeConversion converts one spoke version of a kind to and from the hub HP. Build it with [Spoke].
+type SpokeConversion[HP client.Object] struct {
+	spoke   reflect.Type
+	new     func() client.Object
+	toHub   func(client.Object) HP
+	fromHub func(HP) client.Object
+}
+
+// Spoke is the SpokeConversion between the spoke SP and the hub HP, given the conversions in both directions.
+func Spoke[S any, SP fclient.ObjectPointer[S], HP client.Object](toHub Convert[SP, HP], fromHub Convert[HP, SP]) SpokeConversion[HP] {
+	return SpokeConversion[HP]{
+		spoke:   reflect.TypeFor[SP](),
+		new:     func() client.Object { return SP(new(S)) },
+		toHub:   func(obj client.Object) HP { return toHub(obj.(SP)) },
+		fromHub: func(hub HP) client.Object { return fromHub(hub) },
+	}
+}
+
+// Conversion converts the versions of a kind through its hub. Build it with [Hub].
+type Conversion struct {
+	hub    reflect.Type
+	spokes map[reflect.Type]spoke
+	// versions makes an object of the hub then of each spoke, in registration order.
+	versions []func() client.Object
+}
+
+// spoke is a SpokeConversion with the hub type erased.
+type spoke struct {
+	toHub   func(client.Object) client.Object
+	fromHub func(client.Object) client.Object
+}
+
+// Hub is the Conversion of the kind whose hub, usually the storage version, is HP, to and from each of spokes.
+func Hub[H any, HP fclient.ObjectPointer[H]](spokes ...SpokeConversion[HP]) Conversion {
+	c := Conversion{
+		hub:      reflect.TypeFor[HP](),
+		spokes:   make(map[reflect.Type]spoke, len(spokes)),
+		versions: []func() client.Object{func() client.Object { return HP(new(H)) }},
+	}
+	for _, s := range spokes {
+		c.versions = append(c.versions, s.new)
+		c.spokes[s.spoke] = spoke{
+			toHub:   func(obj client.Object) client.Object { return s.toHub(obj) },
+			fromHub: func(hub client.Object) client.Object { return s.fromHub(hub.(HP)) },
+		}
+	}
+	return c
+}
+
+// Converts reports whether c converts objects of obj's type.
+func (c Conversion) Converts(obj runtime.Object) bool {
+	t := reflect.TypeOf(obj)
+	_, ok := c.spokes[t]
+	return ok || t == c.hub
+}
+
+// Convert converts src into dst, going through the hub when neither is the hub. src is not mutated.
+// The type meta of dst is left for the caller to set.
+func (c Conversion) Convert(src, dst runtime.Object) error {
+	if !c.Converts(src) {
+		return fmt.Errorf("no conversion from %T", src)
+	}
+	if !c.Converts(dst) {
+		return fmt.Errorf("no conversion to %T", dst)
+	}
+	hub := src.DeepCopyObject().(client.Object)
+	if s, ok := c.spokes[reflect.TypeOf(src)]; ok {
+		hub = s.toHub(hub)
+	}
+	out := hub
+	if s, ok := c.spokes[reflect.TypeOf(dst)]; ok {
+		out = s.fromHub(hub)
+	}
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(out).Elem())
+	return nil
+}
+
+// ConversionHandler is the HTTP handler serving the conversion reviews of the API server for the kinds of conversions,
+// decoding objects with scheme.
+//
+// A review fails as a whole when any of its objects cannot be converted, as the API server expects.
+func ConversionHandler(scheme *runtime.Scheme, conversions ...Conversion) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review apiextensionsv1.ConversionReview
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "conversion review has no request", http.StatusBadRequest)
+			return
+		}
+		response := &apiextensionsv1.ConversionResponse{UID: review.Request.UID}
+		converted, err := ET.UnwrapError(convertAll(scheme, conversions, review.Request))
+		if err != nil {
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+		} else {
+			response.ConvertedObjects = converted
+			response.Result = metav1.Status{Status: metav1.StatusSuccess}
+		}
+		review.Request = nil
+		review.Response = response
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(review)
+	})
+}
+
+// Converting is the Setup serving the conversions on the manager's webhook server at [ConvertPath].
+//
+// Do not combine it with controller-runtime's own conversion webhook, which claims the same path
+// for types implementing conversion.Hub.
+func Converting(conversions ...Conversion) fmanager.Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return fmanager.Webhook(ConvertPath, ConversionHandler(mgr.GetScheme(), conversions...))(mgr)
+	}
+}
+
+func convertAll(scheme *runtime.Scheme, conversions []Conversion, req *apiextensionsv1.ConversionRequest) fclient.Either[[]runtime.RawExtension] {
+	desired, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return ET.Left[[]runtime.RawExtension](err)
+	}
+	converted := make([]runtime.RawExtension, 0, len(req.Objects))
+	for _, raw := range req.Objects {
+		obj, err := convertOne(scheme, conversions, raw, desired)
+		if err != nil {
+			return ET.Left[[]runtime.RawExtension](err)
+		}
+		converted = append(converted, runtime.RawExtension{Object: obj})
+	}
+	return ET.Right[error](converted)
+}
+
+func convertOne(scheme *runtime.Scheme, conversions []Conversion, raw runtime.RawExtension, desired schema.GroupVersion) (runtime.Object, error) {
+	var meta metav1.TypeMeta
+	if err := json.Unmarshal(raw.Raw, &meta); err != nil {
+		return nil, err
+	}
+	gvk := meta.GroupVersionKind()
+	src, err := scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw.Raw, src); err != nil {
+		return nil, err
+	}
+	dstGVK := desired.WithKind(gvk.Kind)
+	dst, err := scheme.New(dstGVK)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conversions {
+		if c.Converts(src) {
+			if err := c.Convert(src, dst); err != nil {
+				return nil, fmt.Errorf("converting %s to %s: %w", gvk, dstGVK, err)
+			}
+			dst.GetObjectKind().SetGroupVersionKind(dstGVK)
+			return dst, nil
+		}
+	}
+	return nil, fmt.Errorf("no conversion registered for %s", gvk)
+}
@@ -0,0 +1,126 @@
+package fwebhook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	v2 "github.com/appthrust/fcr/internal/api/v2"
+	"github.com/appthrust/fcr/pkg/fwebhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var catConversion = fwebhook.Hub[v1.Cat](fwebhook.Spoke[v2.Cat](v2.ToV1, v2.FromV1))
+
+var _ = Describe(
+	"Conversion", func() {
+
+		calico := func() *v2.Cat {
+			return &v2.Cat{
+				ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default"},
+				Spec:       v2.CatSpec{Color: "calico", Lives: 9},
+				Status:     &v2.CatStatus{Sleepy: true},
+			}
+		}
+
+		It(
+			"should convert spokes to and from the hub without mutating the source", func() {
+				src := calico()
+				hub := &v1.Cat{}
+				Expect(catConversion.Convert(src, hub)).To(Succeed())
+				Expect(hub.Annotations).To(HaveKeyWithValue(v2.SpecAnnotation, `{"color":"calico","lives":9}`))
+				Expect(hub.Status.Sleepy).To(BeTrue())
+				Expect(src).To(Equal(calico()))
+
+				back := &v2.Cat{}
+				Expect(catConversion.Convert(hub, back)).To(Succeed())
+				Expect(back).To(Equal(calico()))
+			},
+		)
+
+		It(
+			"should reject types it does not convert", func() {
+				Expect(catConversion.Converts(&corev1.ConfigMap{})).To(BeFalse())
+				Expect(catConversion.Convert(&corev1.ConfigMap{}, &v1.Cat{})).To(MatchError(ContainSubstring("no conversion from")))
+				Expect(catConversion.Convert(calico(), &corev1.ConfigMap{})).To(MatchError(ContainSubstring("no conversion to")))
+			},
+		)
+	},
+)
+
+var _ = Describe(
+	"ConversionHandler", func() {
+
+		review := func(desired string, objs ...runtime.Object) *apiextensionsv1.ConversionReview {
+			request := &apiextensionsv1.ConversionRequest{UID: "42", DesiredAPIVersion: desired}
+			for _, obj := range objs {
+				request.Objects = append(request.Objects, raw(obj))
+			}
+			return &apiextensionsv1.ConversionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+				Request:  request,
+			}
+		}
+
+		serve := func(body []byte) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			fwebhook.ConversionHandler(newScheme(), catConversion).
+				ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, fwebhook.ConvertPath, bytes.NewReader(body)))
+			return recorder
+		}
+
+		convert := func(in *apiextensionsv1.ConversionReview) *apiextensionsv1.ConversionResponse {
+			body, err := json.Marshal(in)
+			Expect(err).NotTo(HaveOccurred())
+			recorder := serve(body)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var out apiextensionsv1.ConversionReview
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &out)).To(Succeed())
+			Expect(out.Request).To(BeNil())
+			Expect(out.Response.UID).To(BeEquivalentTo("42"))
+			return out.Response
+		}
+
+		It(
+			"should convert every object to the desired version", func() {
+				cat := &v2.Cat{
+					TypeMeta:   metav1.TypeMeta{APIVersion: v2.GroupVersion.String(), Kind: "Cat"},
+					ObjectMeta: metav1.ObjectMeta{Name: "tom", Namespace: "default"},
+					Spec:       v2.CatSpec{Color: "black"},
+				}
+				response := convert(review(v1.GroupVersion.String(), cat, catNamed("felix", nil)))
+				Expect(response.Result.Status).To(Equal(metav1.StatusSuccess))
+				Expect(response.ConvertedObjects).To(HaveLen(2))
+				var hub v1.Cat
+				Expect(json.Unmarshal(response.ConvertedObjects[0].Raw, &hub)).To(Succeed())
+				Expect(hub.APIVersion).To(Equal(v1.GroupVersion.String()))
+				Expect(hub.Kind).To(Equal("Cat"))
+				Expect(hub.Annotations).To(HaveKeyWithValue(v2.SpecAnnotation, `{"color":"black"}`))
+			},
+		)
+
+		It(
+			"should fail the review when an object cannot be converted", func() {
+				cm := &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}}
+				response := convert(review(v2.GroupVersion.String(), catNamed("tom", nil), cm))
+				Expect(response.Result.Status).To(Equal(metav1.StatusFailure))
+				Expect(response.Result.Message).NotTo(BeEmpty())
+				Expect(response.ConvertedObjects).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should reject malformed reviews", func() {
+				Expect(serve([]byte("not json")).Code).To(Equal(http.StatusBadRequest))
+				Expect(serve([]byte("{}")).Code).To(Equal(http.StatusBadRequest))
+			},
+		)
+	},
+)
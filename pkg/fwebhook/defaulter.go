@@ -0,0 +1,126 @@
+package fwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Defaulting sets defaults on an object of type OP, or fails with the reason the object cannot be admitted.
+type Defaulting[OP any] func(OP) fclient.Either[OP]
+
+// Defaulter is the Defaulting applying the pure function f.
+func Defaulter[T any, OP fclient.ObjectPointer[T]](f func(OP) OP) Defaulting[OP] {
+	return func(obj OP) fclient.Either[OP] {
+		return ET.Right[error](f(obj))
+	}
+}
+
+// DefaulterE is the Defaulting applying f, which may reject the object with a Left.
+func DefaulterE[T any, OP fclient.ObjectPointer[T]](f func(OP) fclient.Either[OP]) Defaulting[OP] {
+	return f
+}
+
+// ComposeDefaulters applies defaulters in order, each to the result of the previous one, stopping at the first Left.
+func ComposeDefaulters[OP any](defaulters ...Defaulting[OP]) Defaulting[OP] {
+	return func(obj OP) fclient.Either[OP] {
+		result := ET.Right[error](obj)
+		for _, d := range defaulters {
+			result = ET.Chain(d)(result)
+		}
+		return result
+	}
+}
+
+// DefaultingHandler is the admission handler applying d to the objects of create and update requests.
+//
+// d receives a deep copy of the incoming object, so it may mutate and return it; the JSON patch from the incoming
+// object to the result is computed automatically. The patch applies to the raw object of the request, and never
+// removes fields that d did not remove, such as fields unknown to T from a newer API version. Other operations
+// are allowed unchanged. A Left denies the request, with the status of the error when it is a Kubernetes API error.
+func DefaultingHandler[T any, OP fclient.ObjectPointer[T]](scheme *runtime.Scheme, d Defaulting[OP]) admission.Handler {
+	decoder := admission.NewDecoder(scheme)
+	return admission.HandlerFunc(func(_ context.Context, req admission.Request) admission.Response {
+		if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+			return admission.Allowed("")
+		}
+		obj := OP(new(T))
+		if err := decoder.Decode(req, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		defaulted, err := ET.UnwrapError(d(obj.DeepCopyObject().(OP)))
+		if err != nil {
+			var apiStatus apierrors.APIStatus
+			if errors.As(err, &apiStatus) {
+				status := apiStatus.Status()
+				return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Allowed: false, Result: &status}}
+			}
+			return admission.Denied(err.Error())
+		}
+		marshaled, err := json.Marshal(defaulted)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		return dropDecodingRemovals(admission.PatchResponseFromRaw(req.Object.Raw, marshaled), obj, req.Object.Raw)
+	})
+}
+
+// dropDecodingRemovals drops the remove operations of r that merely undo the decoding of raw into decoded, such as
+// fields unknown to its type, as controller-runtime's own defaulter does.
+func dropDecodingRemovals(r admission.Response, decoded runtime.Object, raw []byte) admission.Response {
+	isRemove := func(op jsonpatch.JsonPatchOperation) bool { return op.Operation == "remove" }
+	if !r.Allowed || !slices.ContainsFunc(r.Patches, isRemove) {
+		return r
+	}
+	original, err := json.Marshal(decoded)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	decoding, err := jsonpatch.CreatePatch(raw, original)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	r.Patches = slices.DeleteFunc(r.Patches, func(op jsonpatch.JsonPatchOperation) bool {
+		return isRemove(op) && slices.ContainsFunc(decoding, func(d jsonpatch.JsonPatchOperation) bool {
+			return isRemove(d) && d.Path == op.Path
+		})
+	})
+	if len(r.Patches) == 0 {
+		r.PatchType = nil
+	}
+	return r
+}
+
+// Mutating is the Setup serving d on the manager's webhook server at controller-runtime's default path
+// for T, e.g. /mutate-test-appthrust-com-v1-cat.
+func Mutating[T any, OP fclient.ObjectPointer[T]](d Defaulting[OP]) fmanager.Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return func() fclient.Either[fclient.Unit] {
+			gvk, err := apiutil.GVKForObject(OP(new(T)), mgr.GetScheme())
+			if err != nil {
+				return ET.Left[fclient.Unit](err)
+			}
+			webhook := &admission.Webhook{Handler: DefaultingHandler[T](mgr.GetScheme(), d)}
+			return fmanager.Webhook(mutatePath(gvk), webhook)(mgr)()
+		}
+	}
+}
+
+func mutatePath(gvk schema.GroupVersionKind) string {
+	return "/mutate-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
@@ -0,0 +1,195 @@
+package fwebhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	ET "github.com/IBM/fp-go/either"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fwebhook"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe(
+	"Defaulter", func() {
+
+		withLabel := func(key, value string) fwebhook.Defaulting[*v1.Cat] {
+			return fwebhook.Defaulter(func(cat *v1.Cat) *v1.Cat {
+				if cat.Labels == nil {
+					cat.Labels = map[string]string{}
+				}
+				if _, ok := cat.Labels[key]; !ok {
+					cat.Labels[key] = value
+				}
+				return cat
+			})
+		}
+
+		noDogs := fwebhook.DefaulterE(func(cat *v1.Cat) fclient.Either[*v1.Cat] {
+			if cat.Name == "dog" {
+				return ET.Left[*v1.Cat](errors.New("dogs are not defaulted into cats"))
+			}
+			return ET.Right[error](cat)
+		})
+
+		handle := func(d fwebhook.Defaulting[*v1.Cat], req admissionv1.AdmissionRequest) admission.Response {
+			webhook := &admission.Webhook{Handler: fwebhook.DefaultingHandler[v1.Cat](newScheme(), d)}
+			return webhook.Handle(context.TODO(), admission.Request{AdmissionRequest: req})
+		}
+
+		It(
+			"should apply defaulters in order, stopping at the first Left", func() {
+				d := fwebhook.ComposeDefaulters(withLabel("color", "black"), withLabel("color", "white"), withLabel("size", "small"))
+				output, err := ET.UnwrapError(d(catNamed("tom", nil)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.Labels).To(Equal(map[string]string{"color": "black", "size": "small"}))
+
+				output, err = ET.UnwrapError(fwebhook.ComposeDefaulters(noDogs, withLabel("color", "black"))(catNamed("dog", nil)))
+				Expect(err).To(MatchError("dogs are not defaulted into cats"))
+				Expect(output).To(BeNil())
+			},
+		)
+
+		It(
+			"should respond with the JSON patch of the defaults", func() {
+				resp := handle(
+					fwebhook.ComposeDefaulters(withLabel("color", "black"), noDogs),
+					admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("tom", map[string]string{"size": "small"}))},
+				)
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(resp.Patches).To(HaveLen(1))
+				Expect(resp.Patches[0].Operation).To(Equal("add"))
+				Expect(resp.Patches[0].Path).To(Equal("/metadata/labels/color"))
+				Expect(resp.Patches[0].Value).To(Equal("black"))
+			},
+		)
+
+		It(
+			"should not patch objects that already have their defaults", func() {
+				resp := handle(withLabel("color", "black"), admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Object:    raw(catNamed("tom", map[string]string{"color": "white"})),
+					OldObject: raw(catNamed("tom", map[string]string{"color": "white"})),
+				})
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(resp.Patches).To(BeEmpty())
+			},
+		)
+
+		// applied applies the patch of resp to the raw object it was computed for, as the API server does.
+		applied := func(object runtime.RawExtension, resp admission.Response) map[string]any {
+			patched := object.Raw
+			if len(resp.Patches) > 0 {
+				ops, err := json.Marshal(resp.Patches)
+				Expect(err).NotTo(HaveOccurred())
+				patch, err := jsonpatch.DecodePatch(ops)
+				Expect(err).NotTo(HaveOccurred())
+				patched, err = patch.Apply(object.Raw)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			var result map[string]any
+			Expect(json.Unmarshal(patched, &result)).To(Succeed())
+			return result
+		}
+
+		It(
+			"should keep fields unknown to the type and patch only the defaults", func() {
+				unknown := runtime.RawExtension{Raw: []byte(`{
+					"apiVersion": "test.appthrust.com/v1",
+					"kind": "Cat",
+					"metadata": {"name": "tom", "namespace": "default"},
+					"spec": {"newerField": "keep-me"}
+				}`)}
+				identity := fwebhook.Defaulter(func(cat *v1.Cat) *v1.Cat { return cat })
+				resp := handle(identity, admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: unknown})
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(resp.Patches).NotTo(ContainElement(HaveField("Operation", "remove")))
+				Expect(applied(unknown, resp)).To(HaveKeyWithValue("spec", HaveKeyWithValue("newerField", "keep-me")))
+
+				resp = handle(withLabel("color", "black"), admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: unknown})
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(resp.Patches).NotTo(ContainElement(HaveField("Operation", "remove")))
+				patched := applied(unknown, resp)
+				Expect(patched).To(HaveKeyWithValue("spec", HaveKeyWithValue("newerField", "keep-me")))
+				Expect(patched).To(HaveKeyWithValue("metadata", HaveKeyWithValue("labels", HaveKeyWithValue("color", "black"))))
+			},
+		)
+
+		It(
+			"should patch paths the raw object lacks but the type marshals empty", func() {
+				pod := runtime.RawExtension{Raw: []byte(`{
+					"apiVersion": "v1",
+					"kind": "Pod",
+					"metadata": {"name": "tom", "namespace": "default"},
+					"spec": {"containers": [{"name": "cat", "image": "cat"}]}
+				}`)}
+				withLimits := fwebhook.Defaulter(func(pod *corev1.Pod) *corev1.Pod {
+					pod.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+					return pod
+				})
+				webhook := &admission.Webhook{Handler: fwebhook.DefaultingHandler[corev1.Pod](newScheme(), withLimits)}
+				resp := webhook.Handle(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: pod}})
+				Expect(resp.Allowed).To(BeTrue())
+				patched := applied(pod, resp)
+				Expect(patched).To(HaveKeyWithValue("spec", HaveKeyWithValue("containers", ContainElement(
+					HaveKeyWithValue("resources", HaveKeyWithValue("limits", HaveKeyWithValue("cpu", "1"))),
+				))))
+			},
+		)
+
+		It(
+			"should deny objects rejected by a defaulter", func() {
+				resp := handle(noDogs, admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("dog", nil))})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("dogs are not defaulted into cats"))
+
+				invalid := fwebhook.DefaulterE(func(cat *v1.Cat) fclient.Either[*v1.Cat] {
+					return ET.Left[*v1.Cat](error(apierrors.NewInvalid(v1.GroupVersion.WithKind("Cat").GroupKind(), cat.Name, field.ErrorList{
+						field.Required(field.NewPath("metadata", "labels"), ""),
+					})))
+				})
+				resp = handle(invalid, admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("tom", nil))})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonInvalid))
+			},
+		)
+
+		It(
+			"should allow deletes unchanged", func() {
+				resp := handle(noDogs, admissionv1.AdmissionRequest{Operation: admissionv1.Delete, OldObject: raw(catNamed("dog", nil))})
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(resp.Patches).To(BeEmpty())
+			},
+		)
+
+		It(
+			"should register with the manager at the default path", func() {
+				mgr, err := manager.New(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+					Scheme:  newScheme(),
+					Metrics: metricsserver.Options{BindAddress: "0"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ET.IsRight(fwebhook.Mutating[v1.Cat](noDogs)(mgr)())).To(BeTrue())
+				_, pattern := mgr.GetWebhookServer().WebhookMux().Handler(&http.Request{URL: &url.URL{Path: "/mutate-test-appthrust-com-v1-cat"}})
+				Expect(pattern).To(Equal("/mutate-test-appthrust-com-v1-cat"))
+			},
+		)
+	},
+)
@@ -0,0 +1,50 @@
+package fwebhook
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/randfill"
+)
+
+// RoundTrip checks that c is lossless, converting rounds randomly filled objects of each version to every other
+// version and back, and comparing them with the original semantically. Type meta is ignored.
+//
+// The objects are generated from seed, so a failure is reproducible. The error lists every lossy conversion
+// with the difference it introduced.
+func RoundTrip(c Conversion, rounds int, seed int64) error {
+	filler := randfill.NewWithSeed(seed).NilChance(0.2).NumElements(0, 3)
+	var errs []error
+	for round := range rounds {
+		for _, from := range c.versions {
+			for _, to := range c.versions {
+				if err := roundTrip(c, filler, from, to); err != nil {
+					errs = append(errs, fmt.Errorf("round %d: %w", round, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// roundTrip converts a random object made by from to the type made by to with c, and back.
+func roundTrip(c Conversion, filler *randfill.Filler, from, to func() client.Object) error {
+	original, via, back := from(), to(), from()
+	filler.Fill(original)
+	original.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	if err := c.Convert(original, via); err != nil {
+		return err
+	}
+	if err := c.Convert(via, back); err != nil {
+		return err
+	}
+	back.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	if !equality.Semantic.DeepEqual(original, back) {
+		return fmt.Errorf("%T via %T is lossy (-want +got):\n%s", original, via, cmp.Diff(original, back))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package fwebhook_test
+
+import (
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	v2 "github.com/appthrust/fcr/internal/api/v2"
+	"github.com/appthrust/fcr/pkg/fwebhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(
+	"RoundTrip", func() {
+
+		It(
+			"should accept lossless conversions", func() {
+				Expect(fwebhook.RoundTrip(catConversion, 100, GinkgoRandomSeed())).To(Succeed())
+			},
+		)
+
+		It(
+			"should report lossy conversions", func() {
+				forgetful := fwebhook.Hub[v1.Cat](fwebhook.Spoke[v2.Cat](v2.ToV1, func(cat *v1.Cat) *v2.Cat {
+					return &v2.Cat{ObjectMeta: cat.ObjectMeta}
+				}))
+				Expect(fwebhook.RoundTrip(forgetful, 10, GinkgoRandomSeed())).To(MatchError(ContainSubstring("is lossy")))
+			},
+		)
+	},
+)
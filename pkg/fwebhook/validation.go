@@ -0,0 +1,71 @@
+// Package fwebhook provides typed admission webhooks built from pure functions and [fclient] pipelines.
+package fwebhook
+
+import (
+	M "github.com/IBM/fp-go/monoid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Validation is the result of validating an object: every field error found, and warnings for the client.
+//
+// Unlike an error, Validations accumulate: combining two of them with [ValidationMonoid] keeps the errors
+// and warnings of both.
+type Validation struct {
+	Errors   field.ErrorList
+	Warnings admission.Warnings
+}
+
+// Valid is the Validation of an object without errors or warnings.
+func Valid() Validation {
+	return Validation{}
+}
+
+// Invalid is the Validation reporting errs.
+func Invalid(errs ...*field.Error) Validation {
+	return Validation{Errors: errs}
+}
+
+// Warn is the Validation of a valid object that deserves the given warnings.
+func Warn(warnings ...string) Validation {
+	return Validation{Warnings: warnings}
+}
+
+// Check is Valid when ok holds, and reports err otherwise.
+func Check(ok bool, err *field.Error) Validation {
+	if ok {
+		return Valid()
+	}
+	return Invalid(err)
+}
+
+// IsValid reports whether v has no errors.
+func (v Validation) IsValid() bool {
+	return len(v.Errors) == 0
+}
+
+// ToError returns the Invalid status error for v on the object name of kind gk, or nil when v is valid.
+func (v Validation) ToError(gk schema.GroupKind, name string) error {
+	if v.IsValid() {
+		return nil
+	}
+	return apierrors.NewInvalid(gk, name, v.Errors)
+}
+
+// ConcatValidations combines two Validations, keeping the errors and warnings of both in order.
+func ConcatValidations(a, b Validation) Validation {
+	return Validation{
+		Errors:   append(append(field.ErrorList{}, a.Errors...), b.Errors...),
+		Warnings: append(append(admission.Warnings{}, a.Warnings...), b.Warnings...),
+	}
+}
+
+// ValidationMonoid combines Validations with [ConcatValidations]; [Valid] is its identity.
+var ValidationMonoid = M.MakeMonoid(ConcatValidations, Valid())
+
+// All combines validations with [ValidationMonoid].
+func All(validations ...Validation) Validation {
+	return M.ConcatAll(ValidationMonoid)(validations)
+}
@@ -0,0 +1,61 @@
+package fwebhook_test
+
+import (
+	"testing"
+
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	"github.com/appthrust/fcr/pkg/fwebhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestFwebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fwebhook Suite")
+}
+
+var _ = Describe(
+	"Validation", func() {
+
+		name := field.NewPath("metadata", "name")
+		color := field.NewPath("metadata", "labels").Key("color")
+
+		It(
+			"should accumulate every error and warning", func() {
+				v := fwebhook.All(
+					fwebhook.Check(false, field.Invalid(name, "dog", "must be a cat")),
+					fwebhook.Warn("cats sleep a lot"),
+					fwebhook.Check(true, field.Required(color, "")),
+					fwebhook.Invalid(field.Required(color, "every cat has a color")),
+				)
+				Expect(v.IsValid()).To(BeFalse())
+				Expect(v.Errors).To(HaveLen(2))
+				Expect(v.Errors[0].Field).To(Equal("metadata.name"))
+				Expect(v.Errors[1].Field).To(Equal("metadata.labels[color]"))
+				Expect(v.Warnings).To(ConsistOf("cats sleep a lot"))
+			},
+		)
+
+		It(
+			"should have Valid as identity", func() {
+				v := fwebhook.Invalid(field.Required(color, ""))
+				Expect(fwebhook.ValidationMonoid.Concat(fwebhook.Valid(), v)).To(Equal(fwebhook.ConcatValidations(v, fwebhook.Valid())))
+				Expect(fwebhook.All().IsValid()).To(BeTrue())
+			},
+		)
+
+		It(
+			"should convert to an Invalid status error", func() {
+				Expect(fwebhook.Warn("hm").ToError(v1.GroupVersion.WithKind("Cat").GroupKind(), "tom")).To(Succeed())
+				err := fwebhook.Invalid(
+					field.Invalid(name, "dog", "must be a cat"),
+					field.Required(color, ""),
+				).ToError(v1.GroupVersion.WithKind("Cat").GroupKind(), "dog")
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.(apierrors.APIStatus).Status().Details.Causes).To(HaveLen(2))
+			},
+		)
+	},
+)
@@ -0,0 +1,133 @@
+package fwebhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ET "github.com/IBM/fp-go/either"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fmanager"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Validator validates objects of type OP on admission.
+//
+// Create, Update and Delete are pure functions; nil ones accept every object. Cluster, when set, runs for every
+// operation to perform checks that need the cluster, such as uniqueness or reference existence; its Validation
+// is combined with the pure one. A Left from Cluster denies the request with that error.
+type Validator[OP client.Object] struct {
+	Create  func(obj OP) Validation
+	Update  func(oldObj, newObj OP) Validation
+	Delete  func(obj OP) Validation
+	Cluster func(op admissionv1.Operation, obj OP) fclient.ReaderIOEither[Validation]
+}
+
+// CustomValidator adapts v to controller-runtime, running cluster checks with the Env built by env.
+// Use it with admission.WithCustomValidator, or register it with [Validating].
+//
+// Invalid objects are rejected with an Invalid status listing every field error, naming their kind from scheme.
+func (v Validator[OP]) CustomValidator(scheme *runtime.Scheme, env func(context.Context) fclient.Env) admission.CustomValidator {
+	return &customValidator[OP]{validator: v, scheme: scheme, env: env}
+}
+
+// Validating is the Setup serving v on the manager's webhook server at controller-runtime's default path
+// for T, e.g. /validate-test-appthrust-com-v1-cat.
+func Validating[T any, OP fclient.ObjectPointer[T]](v Validator[OP]) fmanager.Setup {
+	return func(mgr manager.Manager) fclient.IOEither[fclient.Unit] {
+		return func() fclient.Either[fclient.Unit] {
+			gvk, err := apiutil.GVKForObject(OP(new(T)), mgr.GetScheme())
+			if err != nil {
+				return ET.Left[fclient.Unit](err)
+			}
+			path := validatePath(gvk)
+			webhook := admission.WithCustomValidator(mgr.GetScheme(), OP(new(T)), v.CustomValidator(mgr.GetScheme(), fmanager.EnvFor(mgr, path)))
+			return fmanager.Webhook(path, webhook)(mgr)()
+		}
+	}
+}
+
+func validatePath(gvk schema.GroupVersionKind) string {
+	return "/validate-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+// customValidator implements [admission.CustomValidator] for a Validator.
+type customValidator[OP client.Object] struct {
+	validator Validator[OP]
+	scheme    *runtime.Scheme
+	env       func(context.Context) fclient.Env
+}
+
+func (c *customValidator[OP]) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	typed, err := cast[OP](obj)
+	if err != nil {
+		return nil, err
+	}
+	return c.validate(ctx, admissionv1.Create, typed, orValid(c.validator.Create, typed))
+}
+
+func (c *customValidator[OP]) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTyped, err := cast[OP](oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newTyped, err := cast[OP](newObj)
+	if err != nil {
+		return nil, err
+	}
+	pure := Valid()
+	if c.validator.Update != nil {
+		pure = c.validator.Update(oldTyped, newTyped)
+	}
+	return c.validate(ctx, admissionv1.Update, newTyped, pure)
+}
+
+func (c *customValidator[OP]) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	typed, err := cast[OP](obj)
+	if err != nil {
+		return nil, err
+	}
+	return c.validate(ctx, admissionv1.Delete, typed, orValid(c.validator.Delete, typed))
+}
+
+// validate combines the pure Validation with the cluster checks and turns the result into an admission answer.
+func (c *customValidator[OP]) validate(ctx context.Context, op admissionv1.Operation, obj OP, pure Validation) (admission.Warnings, error) {
+	result := pure
+	if c.validator.Cluster != nil {
+		cluster, err := ET.UnwrapError(c.validator.Cluster(op, obj)(c.env(ctx))())
+		if err != nil {
+			return pure.Warnings, err
+		}
+		result = ConcatValidations(pure, cluster)
+	}
+	if result.IsValid() {
+		return result.Warnings, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return result.Warnings, err
+	}
+	return result.Warnings, result.ToError(gvk.GroupKind(), obj.GetName())
+}
+
+func orValid[OP any](f func(OP) Validation, obj OP) Validation {
+	if f == nil {
+		return Valid()
+	}
+	return f(obj)
+}
+
+func cast[OP client.Object](obj runtime.Object) (OP, error) {
+	typed, ok := obj.(OP)
+	if !ok {
+		var zero OP
+		return zero, fmt.Errorf("expected %T but got %T", zero, obj)
+	}
+	return typed, nil
+}
@@ -0,0 +1,189 @@
+package fwebhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	ET "github.com/IBM/fp-go/either"
+	RIOE "github.com/IBM/fp-go/readerioeither"
+	v1 "github.com/appthrust/fcr/internal/api/v1"
+	v2 "github.com/appthrust/fcr/internal/api/v2"
+	"github.com/appthrust/fcr/pkg/fclient"
+	"github.com/appthrust/fcr/pkg/fwebhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// newScheme returns a scheme aware of the test API types.
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(v1.AddToScheme(scheme)).To(Succeed())
+	Expect(v2.AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+// raw encodes obj as the raw object of an admission request.
+func raw(obj runtime.Object) runtime.RawExtension {
+	data, err := json.Marshal(obj)
+	Expect(err).NotTo(HaveOccurred())
+	return runtime.RawExtension{Raw: data}
+}
+
+func catNamed(name string, labels map[string]string) *v1.Cat {
+	return &v1.Cat{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "Cat"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+	}
+}
+
+var _ = Describe(
+	"Validator", func() {
+
+		var webhook *admission.Webhook
+		var cl client.Client
+
+		color := field.NewPath("metadata", "labels").Key("color")
+
+		validator := fwebhook.Validator[*v1.Cat]{
+			Create: func(cat *v1.Cat) fwebhook.Validation {
+				return fwebhook.All(
+					fwebhook.Check(cat.Name != "dog", field.Invalid(field.NewPath("metadata", "name"), cat.Name, "must be a cat")),
+					fwebhook.Check(cat.Labels["color"] != "", field.Required(color, "every cat has a color")),
+				)
+			},
+			Update: func(oldCat, newCat *v1.Cat) fwebhook.Validation {
+				return fwebhook.Check(oldCat.Labels["color"] == newCat.Labels["color"], field.Forbidden(color, "cats do not change color"))
+			},
+			Cluster: func(op admissionv1.Operation, cat *v1.Cat) fclient.ReaderIOEither[fwebhook.Validation] {
+				if op != admissionv1.Create {
+					return RIOE.Right[fclient.Env, error](fwebhook.Valid())
+				}
+				return func(env fclient.Env) fclient.IOEither[fwebhook.Validation] {
+					return func() fclient.Either[fwebhook.Validation] {
+						if cat.Labels["color"] == "plaid" {
+							return RIOE.Left[fclient.Env, fwebhook.Validation](errors.New("color registry unavailable"))(env)()
+						}
+						var cats v1.CatList
+						if err := env.Client.List(env.Ctx, &cats, client.InNamespace(cat.Namespace)); err != nil {
+							return RIOE.Left[fclient.Env, fwebhook.Validation](err)(env)()
+						}
+						return RIOE.Right[fclient.Env, error](fwebhook.All(
+							fwebhook.Check(len(cats.Items) < 2, field.TooMany(field.NewPath("metadata", "namespace"), len(cats.Items)+1, 2)),
+							fwebhook.Warn("new cats need a litter box"),
+						))(env)()
+					}
+				}
+			},
+		}
+
+		BeforeEach(
+			func() {
+				scheme := newScheme()
+				cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(catNamed("tom", nil)).Build()
+				webhook = admission.WithCustomValidator(scheme, &v1.Cat{}, validator.CustomValidator(scheme, func(ctx context.Context) fclient.Env {
+					return fclient.Env{Ctx: ctx, Client: cl}
+				}))
+			},
+		)
+
+		handle := func(req admissionv1.AdmissionRequest) admission.Response {
+			return webhook.Handle(context.TODO(), admission.Request{AdmissionRequest: req})
+		}
+
+		It(
+			"should allow valid objects with the warnings of every check", func() {
+				resp := handle(admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("felix", map[string]string{"color": "black"}))})
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(resp.Warnings).To(ConsistOf("new cats need a litter box"))
+			},
+		)
+
+		It(
+			"should deny invalid objects with every field error", func() {
+				resp := handle(admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("dog", nil))})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Code).To(Equal(int32(http.StatusUnprocessableEntity)))
+				Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonInvalid))
+				Expect(resp.Result.Details.Causes).To(HaveLen(2))
+			},
+		)
+
+		It(
+			"should not need a client without cluster checks", func() {
+				pure := validator
+				pure.Cluster = nil
+				scheme := newScheme()
+				webhook := admission.WithCustomValidator(scheme, &v1.Cat{}, pure.CustomValidator(scheme, func(ctx context.Context) fclient.Env {
+					return fclient.Env{Ctx: ctx}
+				}))
+				resp := webhook.Handle(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    raw(catNamed("dog", nil)),
+				}})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonInvalid))
+			},
+		)
+
+		It(
+			"should combine cluster checks with the pure ones", func() {
+				Expect(cl.Create(context.TODO(), catNamed("felix", nil))).To(Succeed())
+				resp := handle(admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("dog", map[string]string{"color": "black"}))})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(2))
+				Expect(resp.Warnings).To(ConsistOf("new cats need a litter box"))
+			},
+		)
+
+		It(
+			"should deny with the error of a failing cluster check", func() {
+				resp := handle(admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: raw(catNamed("felix", map[string]string{"color": "plaid"}))})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("color registry unavailable"))
+			},
+		)
+
+		It(
+			"should validate updates against the old object and accept deletes", func() {
+				resp := handle(admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					OldObject: raw(catNamed("tom", map[string]string{"color": "black"})),
+					Object:    raw(catNamed("tom", map[string]string{"color": "white"})),
+				})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal("metadata.labels[color]"))
+
+				resp = handle(admissionv1.AdmissionRequest{Operation: admissionv1.Delete, OldObject: raw(catNamed("tom", nil))})
+				Expect(resp.Allowed).To(BeTrue())
+			},
+		)
+
+		It(
+			"should register with the manager at the default path", func() {
+				mgr, err := manager.New(&rest.Config{Host: "https://127.0.0.1:1"}, manager.Options{
+					Scheme:  newScheme(),
+					Metrics: metricsserver.Options{BindAddress: "0"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ET.IsRight(fwebhook.Validating[v1.Cat](validator)(mgr)())).To(BeTrue())
+				_, pattern := mgr.GetWebhookServer().WebhookMux().Handler(&http.Request{URL: &url.URL{Path: "/validate-test-appthrust-com-v1-cat"}})
+				Expect(pattern).To(Equal("/validate-test-appthrust-com-v1-cat"))
+			},
+		)
+	},
+)